@@ -16,21 +16,35 @@
 package clustercmd
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
 
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
 )
 
+// operationNames are the cluster operations as used in the error messages.
+var operationNames = map[string]string{
+	constants.ClusterGetState:            "get the state",
+	constants.ClusterChangeState:         "change the state",
+	constants.ClusterVersion:             "get the version",
+	constants.ClusterShutdown:            "shut down",
+	constants.MemberShutdown:             "shut down the member",
+	constants.PersistenceForceStart:      "force start",
+	constants.PersistencePartialStart:    "partially start",
+	constants.PersistenceBackup:          "back up the persisted data",
+	constants.PersistenceBackupInterrupt: "interrupt the backup",
+}
+
 const invocationOnCloudInfoMessage = "Cluster operations on cloud are not supported. Checkout https://github.com/hazelcast/hazelcast-cloud-cli for cluster management on cloud."
 
 func New(config *hazelcast.Config) *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "cluster {get-state | change-state | shutdown | query} [--state new-state]",
+		Use:   "cluster {get-state | change-state | shutdown | query | members | health | persistence | log-level | license | info} [--state new-state]",
 		Short: "Administrative cluster operations",
 		Long:  `Administrative cluster operations which controls a Hazelcast cluster by manipulating its state and other features`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
@@ -47,10 +61,6 @@ func New(config *hazelcast.Config) *cobra.Command {
 		command string
 		info    string
 	}{
-		{
-			command: "shutdown",
-			info:    "shuts down the cluster",
-		},
 		{
 			command: "version",
 			info:    "retrieve information from the cluster",
@@ -69,43 +79,68 @@ func New(config *hazelcast.Config) *cobra.Command {
 			PreRunE: hzcerrors.RequiredFlagChecker,
 			RunE: func(cmd *cobra.Command, args []string) error {
 				defer hzcerrors.ErrorRecover(cmd.ErrOrStderr())
-				result, err := connection.CallClusterOperation(config, sc.command)
+				result, err := connection.CallClusterOperation(cmd.Context(), config, sc.command)
+				if err != nil {
+					return err
+				}
+				text, err := describeResponse(sc.command, result.Body)
 				if err != nil {
 					return err
 				}
-				cmd.Println(*result)
+				cmd.Println(text)
+				printAnsweringMember(cmd, result)
 				return nil
 			},
 		})
 	}
-	// adding this explicitly, since it is a bit different from the rest
+	// adding these explicitly, since they are different from the rest
+	cmd.AddCommand(NewShutdown(config))
 	cmd.AddCommand(NewChangeState(config))
+	cmd.AddCommand(NewMembers(config))
+	cmd.AddCommand(NewHealth(config))
+	cmd.AddCommand(NewPersistence(config))
+	cmd.AddCommand(NewLogLevel(config))
+	cmd.AddCommand(NewLicense(config))
+	cmd.AddCommand(NewInfo(config))
 	return &cmd
 }
 
-var states = []string{"active", "no_migration", "frozen", "passive"}
+// describeResponse returns a human readable text of the response, or the response itself if it is not JSON.
+func describeResponse(operation, body string) (string, error) {
+	if !json.Valid([]byte(body)) {
+		return body, nil
+	}
+	r, err := connection.ParseRESTResponse(body)
+	if err != nil {
+		return "", hzcerrors.NewLoggableError(err, "The cluster could not %s: %s", operationNames[operation], err)
+	}
+	switch operation {
+	case constants.ClusterGetState:
+		return fmt.Sprintf("The cluster state is %s", r.State), nil
+	case constants.ClusterChangeState:
+		return fmt.Sprintf("Changed the cluster state to %s", r.State), nil
+	case constants.ClusterVersion:
+		return fmt.Sprintf("The cluster version is %s", r.Version), nil
+	case constants.ClusterShutdown:
+		return "The cluster is shutting down", nil
+	case constants.MemberShutdown:
+		return "The member is shutting down", nil
+	case constants.PersistenceForceStart:
+		return "Requested the force start of the cluster", nil
+	case constants.PersistencePartialStart:
+		return "Requested the partial start of the cluster", nil
+	case constants.PersistenceBackup:
+		return "Started the backup of the persisted data", nil
+	case constants.PersistenceBackupInterrupt:
+		return "Interrupted the backup of the persisted data", nil
+	}
+	return body, nil
+}
 
-func NewChangeState(config *hazelcast.Config) *cobra.Command {
-	// monitored flag variable
-	var newState string
-	cmd := &cobra.Command{
-		Use:     fmt.Sprintf("change-state [--state [%s]]", strings.Join(states, ",")),
-		Short:   "Change state of the cluster",
-		PreRunE: hzcerrors.RequiredFlagChecker,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			defer hzcerrors.ErrorRecover(cmd.ErrOrStderr())
-			result, err := connection.CallClusterOperationWithState(config, "change-state", &newState)
-			if err != nil {
-				return err
-			}
-			fmt.Println(*result)
-			return nil
-		},
+// printAnsweringMember writes the member which answered, and the members tried before it, to the standard error.
+func printAnsweringMember(cmd *cobra.Command, result *connection.ClusterOperationResult) {
+	for _, m := range result.Unreachable {
+		cmd.PrintErrf("Member %s did not answer\n", m)
 	}
-	cmd.Flags().StringVarP(&newState, "state", "s", "", fmt.Sprintf("new state of the cluster: %s", strings.Join(states, ",")))
-	cmd.MarkFlagRequired("state")
-	cmd.RegisterFlagCompletionFunc("state", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return states, cobra.ShellCompDirectiveDefault
-	})
-	return cmd
+	cmd.PrintErrf("Answered by member %s\n", result.Member)
 }
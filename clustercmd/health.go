@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+)
+
+// clusterHealth is the health of all members, written as the JSON output.
+type clusterHealth struct {
+	Healthy bool                      `json:"healthy"`
+	Members []connection.MemberHealth `json:"members"`
+}
+
+func NewHealth(config *hazelcast.Config) *cobra.Command {
+	var (
+		outputType string
+		timeout    time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "health [--output-type type] [--timeout duration]",
+		Short: "Check the health and readiness of the members",
+		Long: `Check the health and readiness of each member using its health check endpoints, which must be enabled on the members with the hazelcast.http.healthcheck.enabled property.
+The members are discovered by connecting to the cluster, the configured addresses are checked if the connection fails.
+Exits with a non-zero code if a member does not answer, is not ready or reports that the cluster is not safe, e.g. while partitions are migrated.`,
+		Example: `  cluster health
+  cluster health --output-type json --timeout 30s`,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputType(outputType); err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			health := checkHealth(ctx, config, memberAddresses(ctx, config))
+			var err error
+			if outputType == outputJSON {
+				err = writeJSON(cmd.OutOrStdout(), health)
+			} else {
+				err = writeHealth(cmd.OutOrStdout(), health)
+			}
+			if err != nil {
+				return err
+			}
+			if !health.Healthy {
+				return hzcerrors.NewLoggableError(nil, "The cluster is not healthy:\n%s", health.problems())
+			}
+			return nil
+		},
+	}
+	addOutputTypeFlag(cmd, &outputType)
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "time to discover and check the members")
+	return cmd
+}
+
+// checkHealth checks the members concurrently, keeping their order.
+func checkHealth(ctx context.Context, config *hazelcast.Config, addresses []string) clusterHealth {
+	health := clusterHealth{
+		Healthy: len(addresses) > 0,
+		Members: make([]connection.MemberHealth, len(addresses)),
+	}
+	var wg sync.WaitGroup
+	for i, a := range addresses {
+		wg.Add(1)
+		go func(i int, a string) {
+			defer wg.Done()
+			health.Members[i] = connection.GetMemberHealth(ctx, config, a)
+		}(i, a)
+	}
+	wg.Wait()
+	for _, m := range health.Members {
+		health.Healthy = health.Healthy && m.Healthy()
+	}
+	return health
+}
+
+// problems returns why the members are not healthy, one member per line.
+func (h clusterHealth) problems() string {
+	if len(h.Members) == 0 {
+		return "no members to check"
+	}
+	var problems []string
+	for _, m := range h.Members {
+		if p := m.Problem(); p != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", m.Member, p))
+		}
+	}
+	return strings.Join(problems, "\n")
+}
+
+func writeHealth(out io.Writer, health clusterHealth) error {
+	tw := table.NewTableWriter(out)
+	if err := tw.WriteHeader("Member", "Node State", "Cluster State", "Cluster Safe", "Migration Queue", "Cluster Size", "Ready"); err != nil {
+		return err
+	}
+	tw.AlignRight(4, 5)
+	for _, m := range health.Members {
+		if m.Error != "" {
+			if err := tw.Write(m.Member, "UNKNOWN", "", "", "", "", false); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := tw.Write(m.Member, m.NodeState, m.ClusterState, m.ClusterSafe, m.MigrationQueueSize, m.ClusterSize, m.Ready); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, m := range health.Members {
+		if m.Error != "" {
+			if _, err := fmt.Fprintf(out, "%s: %s\n", m.Member, m.Error); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package clustercmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+func healthServer(t *testing.T, safe string, ready bool) string {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case constants.ClusterHealthEndpoint:
+			w.Write([]byte(`{"nodeState":"ACTIVE","clusterState":"ACTIVE","clusterSafe":` + safe + `,"migrationQueueSize":3,"clusterSize":2}`))
+		case constants.ClusterReadyEndpoint:
+			if !ready {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func TestCheckHealth(t *testing.T) {
+	var config hazelcast.Config
+	healthy := healthServer(t, "true", true)
+	health := checkHealth(context.Background(), &config, []string{healthy})
+	require.True(t, health.Healthy)
+	require.Equal(t, 3, health.Members[0].MigrationQueueSize)
+	disabled := httptest.NewServer(http.NotFoundHandler())
+	defer disabled.Close()
+	unsafe := healthServer(t, "false", true)
+	notReady := healthServer(t, "true", false)
+	tcs := []struct {
+		addresses []string
+		problems  string
+	}{
+		{addresses: []string{healthy, unsafe}, problems: unsafe + ": cluster is not safe"},
+		{addresses: []string{healthy, notReady}, problems: notReady + ": not ready"},
+		{
+			addresses: []string{healthy, strings.TrimPrefix(disabled.URL, "http://")},
+			problems:  strings.TrimPrefix(disabled.URL, "http://") + ": cannot check the health: health check responded with Not Found, it may be disabled on the member",
+		},
+		{problems: "no members to check"},
+	}
+	for _, tc := range tcs {
+		health := checkHealth(context.Background(), &config, tc.addresses)
+		require.False(t, health.Healthy, tc.addresses)
+		require.Equal(t, tc.problems, health.problems())
+	}
+}
+
+func TestWriteHealth(t *testing.T) {
+	var config hazelcast.Config
+	member := healthServer(t, "true", false)
+	health := checkHealth(context.Background(), &config, []string{member})
+	var b bytes.Buffer
+	require.NoError(t, writeHealth(&b, health))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Regexp(t, `^\| `+member+` \| ACTIVE +\| ACTIVE +\| true +\| +3 \| +2 \| false \|$`, lines[3])
+	b.Reset()
+	require.NoError(t, writeJSON(&b, health))
+	require.Contains(t, b.String(), `"healthy": false`)
+	require.Contains(t, b.String(), `"migrationQueueSize": 3`)
+}
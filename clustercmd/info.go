@@ -0,0 +1,292 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+)
+
+// license is the license of the cluster, the member reports the expiry date in milliseconds.
+type license struct {
+	Member       string          `json:"member"`
+	CompanyName  string          `json:"companyName"`
+	OwnerEmail   string          `json:"ownerEmail"`
+	ExpiryDate   int64           `json:"expiryDate"`
+	MaxNodeCount int             `json:"maxNodeCount"`
+	Features     json.RawMessage `json:"features,omitempty"`
+}
+
+func (l license) expiry() time.Time {
+	return time.UnixMilli(l.ExpiryDate)
+}
+
+// featureNames returns the features as text, the features are names or objects depending on the member version.
+func (l license) featureNames() []string {
+	var names []string
+	if len(l.Features) == 0 || json.Unmarshal(l.Features, &names) == nil {
+		return names
+	}
+	return []string{string(l.Features)}
+}
+
+// clusterInfo is the report of the info command. The parts which could not be retrieved are left empty and their
+// errors are listed.
+type clusterInfo struct {
+	Name           string       `json:"name"`
+	State          string       `json:"state,omitempty"`
+	Version        string       `json:"version,omitempty"`
+	PartitionCount int32        `json:"partitionCount,omitempty"`
+	Members        []memberInfo `json:"members"`
+	Errors         []string     `json:"errors,omitempty"`
+}
+
+type memberInfo struct {
+	UUID       string `json:"uuid"`
+	Address    string `json:"address"`
+	Version    string `json:"version"`
+	LiteMember bool   `json:"liteMember"`
+	// ClientConnections is the number of clients connected to the member, nil if it is not known
+	ClientConnections *int `json:"clientConnections,omitempty"`
+}
+
+var connectionCountRegex = regexp.MustCompile(`(?m)^ConnectionCount: (\d+)`)
+
+func NewLicense(config *hazelcast.Config) *cobra.Command {
+	var outputType string
+	cmd := &cobra.Command{
+		Use:     "license [--output-type type]",
+		Short:   "Show the license of the cluster",
+		Long:    `Show the license of the cluster with its expiry date, the allowed number of members and the licensed features, which is available on Hazelcast Enterprise only.`,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputType(outputType); err != nil {
+				return err
+			}
+			result, err := connection.CallClusterOperation(cmd.Context(), config, constants.ClusterLicense)
+			if err != nil {
+				return err
+			}
+			l, err := parseLicense(result.Body)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Can not get the license: %s", err)
+			}
+			l.Member = result.Member
+			if outputType == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), l)
+			}
+			return writeLicense(cmd.OutOrStdout(), l, time.Now())
+		},
+	}
+	addOutputTypeFlag(cmd, &outputType)
+	return cmd
+}
+
+func parseLicense(body string) (license, error) {
+	var response struct {
+		LicenseInfo *license `json:"licenseInfo"`
+	}
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return license{}, fmt.Errorf("unexpected response %q: %w", body, err)
+	}
+	if response.LicenseInfo == nil {
+		return license{}, fmt.Errorf("the cluster has no license, it may not be a Hazelcast Enterprise cluster")
+	}
+	return *response.LicenseInfo, nil
+}
+
+func writeLicense(out io.Writer, l license, now time.Time) error {
+	expiry := l.expiry().Format("2006-01-02")
+	if days := int(l.expiry().Sub(now).Hours() / 24); days >= 0 {
+		expiry = fmt.Sprintf("%s (in %d days)", expiry, days)
+	} else {
+		expiry = fmt.Sprintf("%s (expired)", expiry)
+	}
+	return writeFields(out, [][2]string{
+		{"Company", l.CompanyName},
+		{"Owner Email", l.OwnerEmail},
+		{"Expiry Date", expiry},
+		{"Max Members", strconv.Itoa(l.MaxNodeCount)},
+		{"Features", strings.Join(l.featureNames(), ", ")},
+	})
+}
+
+func NewInfo(config *hazelcast.Config) *cobra.Command {
+	var (
+		outputType string
+		timeout    time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "info [--output-type type] [--timeout duration]",
+		Short: "Show a report of the cluster",
+		Long: `Show the cluster name, state, version, partition count and the members with the number of clients connected to them, e.g. to attach to a support ticket.
+The parts of the report which can not be retrieved are listed with their errors. --timeout bounds the whole report, including the calls to the members.`,
+		Example: `  cluster info
+  cluster info --output-type json > cluster-info.json`,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputType(outputType); err != nil {
+				return err
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			info := collectInfo(ctx, config)
+			if outputType == outputJSON {
+				return writeJSON(cmd.OutOrStdout(), info)
+			}
+			return writeInfo(cmd.OutOrStdout(), info)
+		},
+	}
+	addOutputTypeFlag(cmd, &outputType)
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "time to collect the report, including connecting to the cluster and calling the members")
+	return cmd
+}
+
+func collectInfo(ctx context.Context, config *hazelcast.Config) clusterInfo {
+	info := clusterInfo{Name: config.Cluster.Name}
+	if result, err := connection.CallClusterOperation(ctx, config, constants.ClusterGetState); err != nil {
+		info.Errors = append(info.Errors, fmt.Sprintf("state: %s", err))
+	} else if r, err := connection.ParseRESTResponse(result.Body); err != nil {
+		info.Errors = append(info.Errors, fmt.Sprintf("state: %s", err))
+	} else {
+		info.State = r.State
+	}
+	if result, err := connection.CallClusterOperation(ctx, config, constants.ClusterVersion); err != nil {
+		info.Errors = append(info.Errors, fmt.Sprintf("version: %s", err))
+	} else if r, err := connection.ParseRESTResponse(result.Body); err != nil {
+		info.Errors = append(info.Errors, fmt.Sprintf("version: %s", err))
+	} else {
+		info.Version = r.Version
+	}
+	c, err := connection.ConnectToCluster(ctx, config)
+	if err != nil {
+		info.Errors = append(info.Errors, fmt.Sprintf("members: %s", err))
+		return info
+	}
+	if count, ok := partitionCount(c); ok {
+		info.PartitionCount = count
+	}
+	members, err := connection.Members(ctx)
+	if err != nil {
+		info.Errors = append(info.Errors, fmt.Sprintf("members: %s", err))
+		return info
+	}
+	for _, m := range members {
+		mi := memberInfo{
+			UUID:       m.UUID.String(),
+			Address:    m.Address.String(),
+			Version:    memberVersion(m.Version),
+			LiteMember: m.LiteMember,
+		}
+		if n, err := clientConnections(ctx, config, mi.Address); err != nil {
+			info.Errors = append(info.Errors, fmt.Sprintf("client connections of %s: %s", mi.Address, err))
+		} else {
+			mi.ClientConnections = &n
+		}
+		info.Members = append(info.Members, mi)
+	}
+	return info
+}
+
+// clientConnections returns the number of clients connected to the member.
+func clientConnections(ctx context.Context, config *hazelcast.Config, member string) (int, error) {
+	body, err := connection.CallMemberOperation(ctx, config, member, constants.ClusterInfo, "")
+	if err != nil {
+		return 0, err
+	}
+	m := connectionCountRegex.FindStringSubmatch(body)
+	if m == nil {
+		return 0, fmt.Errorf("unexpected response %q", body)
+	}
+	return strconv.Atoi(m[1])
+}
+
+func writeInfo(out io.Writer, info clusterInfo) error {
+	partitions := "unknown"
+	if info.PartitionCount > 0 {
+		partitions = strconv.Itoa(int(info.PartitionCount))
+	}
+	err := writeFields(out, [][2]string{
+		{"Cluster Name", info.Name},
+		{"State", info.State},
+		{"Version", info.Version},
+		{"Partitions", partitions},
+		{"Members", strconv.Itoa(len(info.Members))},
+	})
+	if err != nil {
+		return err
+	}
+	if len(info.Members) > 0 {
+		tw := table.NewTableWriter(out)
+		if err := tw.WriteHeader("UUID", "Address", "Version", "Lite Member", "Client Connections"); err != nil {
+			return err
+		}
+		tw.AlignRight(4)
+		for _, m := range info.Members {
+			clients := "unknown"
+			if m.ClientConnections != nil {
+				clients = strconv.Itoa(*m.ClientConnections)
+			}
+			if err := tw.Write(m.UUID, m.Address, m.Version, m.LiteMember, clients); err != nil {
+				return err
+			}
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+	for _, e := range info.Errors {
+		if _, err := fmt.Fprintf(out, "Could not get the %s\n", e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFields writes the name and value pairs as lines, aligning the values.
+func writeFields(out io.Writer, fields [][2]string) error {
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f[0]))
+	}
+	for _, f := range fields {
+		if _, err := fmt.Fprintf(out, "%-*s: %s\n", width, f[0], f[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
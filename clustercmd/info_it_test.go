@@ -0,0 +1,43 @@
+//go:build hazelcastinternal
+// +build hazelcastinternal
+
+package clustercmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/clustercmd"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/it"
+)
+
+func TestInfo_Cluster(t *testing.T) {
+	it.MapTesterWithConfigAndMapName(t, func(t *testing.T, c *hazelcast.Config, m *hazelcast.Map, n string) {
+		ctx := context.Background()
+		defer func() {
+			require.NoError(t, connection.Disconnect(ctx))
+			connection.ResetClient()
+		}()
+		cmd := clustercmd.NewInfo(c)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetArgs([]string{"--output-type", "json"})
+		_, err := cmd.ExecuteContextC(ctx)
+		require.NoError(t, err)
+		var info struct {
+			PartitionCount int32 `json:"partitionCount"`
+			Members        []struct {
+				Address string `json:"address"`
+			} `json:"members"`
+		}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &info))
+		require.Equal(t, int32(271), info.PartitionCount)
+		require.NotEmpty(t, info.Members)
+	})
+}
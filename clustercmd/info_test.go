@@ -0,0 +1,70 @@
+package clustercmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+func TestLicense(t *testing.T) {
+	l, err := parseLicense(`{"licenseInfo":{"expiryDate":1672531200000,"maxNodeCount":10,"type":-1,"companyName":"Acme","ownerEmail":"ops@acme.com","keyHash":"x","features":["HD_MEMORY","PERSISTENCE"]}}`)
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, writeLicense(&b, l, time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)))
+	require.Equal(t, `Company    : Acme
+Owner Email: ops@acme.com
+Expiry Date: 2023-01-01 (in 31 days)
+Max Members: 10
+Features   : HD_MEMORY, PERSISTENCE
+`, b.String())
+	_, err = parseLicense(`{}`)
+	require.Error(t, err)
+}
+
+func TestClientConnections(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, constants.ClusterInfoEndpoint, r.URL.Path)
+		w.Write([]byte("Members {size:1, ver:1} [\n\tMember [127.0.0.1]:5701 - 3e1a this\n]\n\nConnectionCount: 3\nAllConnectionCount: 4\n"))
+	}))
+	defer s.Close()
+	var config hazelcast.Config
+	n, err := clientConnections(context.Background(), &config, strings.TrimPrefix(s.URL, "http://"))
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+}
+
+func TestWriteInfo(t *testing.T) {
+	clients := 2
+	info := clusterInfo{
+		Name:    "dev",
+		State:   "active",
+		Version: "5.2",
+		Members: []memberInfo{
+			{UUID: "3e1a", Address: "127.0.0.1:5701", Version: "5.2.0", ClientConnections: &clients},
+			{UUID: "7b2c", Address: "127.0.0.1:5702", Version: "5.2.0"},
+		},
+		Errors: []string{"client connections of 127.0.0.1:5702: timeout"},
+	}
+	var b bytes.Buffer
+	require.NoError(t, writeInfo(&b, info))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Equal(t, []string{
+		"Cluster Name: dev",
+		"State       : active",
+		"Version     : 5.2",
+		"Partitions  : unknown",
+		"Members     : 2",
+	}, lines[:5])
+	require.Regexp(t, `^\| 3e1a \| 127.0.0.1:5701 \| 5.2.0 +\| false +\| +2 \|$`, lines[8])
+	require.Regexp(t, `^\| 7b2c \| 127.0.0.1:5702 \| 5.2.0 +\| false +\| +unknown \|$`, lines[9])
+	require.Equal(t, "Could not get the client connections of 127.0.0.1:5702: timeout", lines[10])
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+)
+
+// logLevels are the levels of java.util.logging, which the members accept.
+var logLevels = []string{"OFF", "SEVERE", "WARNING", "INFO", "CONFIG", "FINE", "FINER", "FINEST", "ALL"}
+
+// memberResult is the outcome of an operation on a member, err is nil if the member accepted it.
+type memberResult struct {
+	member string
+	err    error
+}
+
+func NewLogLevel(config *hazelcast.Config) *cobra.Command {
+	var timeout time.Duration
+	cmd := cobra.Command{
+		Use:   "log-level {set level | reset}",
+		Short: "Change the log level of the members",
+		Long: `Change the log level of all members at runtime, or reset it to the level of the member configuration.
+The members are discovered by connecting to the cluster, the configured addresses are used if the connection fails.`,
+		Example: `  cluster log-level set FINE
+  cluster log-level reset`,
+		DisableFlagParsing: true,
+		RunE:               hzcerrors.RootRunnerFnc,
+	}
+	set := &cobra.Command{
+		Use:       fmt.Sprintf("set {%s}", strings.Join(logLevels, " | ")),
+		Short:     "set the log level of all members",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: logLevels,
+		PreRunE:   hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level := strings.ToUpper(args[0])
+			if !isLogLevel(level) {
+				return hzcerrors.NewLoggableError(nil, "Invalid log level %q, it should be one of %s", args[0], strings.Join(logLevels, ", "))
+			}
+			return changeLogLevel(cmd, config, timeout, constants.LogLevelSet, level)
+		},
+	}
+	reset := &cobra.Command{
+		Use:     "reset",
+		Short:   "reset the log level of all members to the configured level",
+		Args:    cobra.NoArgs,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return changeLogLevel(cmd, config, timeout, constants.LogLevelReset, "")
+		},
+	}
+	for _, c := range []*cobra.Command{set, reset} {
+		c.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "time to discover and update the members")
+		cmd.AddCommand(c)
+	}
+	return &cmd
+}
+
+func isLogLevel(level string) bool {
+	for _, l := range logLevels {
+		if l == level {
+			return true
+		}
+	}
+	return false
+}
+
+func changeLogLevel(cmd *cobra.Command, config *hazelcast.Config, timeout time.Duration, operation, level string) error {
+	ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+	defer cancel()
+	results := callMembers(ctx, config, memberAddresses(ctx, config), operation, level)
+	if err := writeMemberResults(cmd.OutOrStdout(), results); err != nil {
+		return err
+	}
+	return failedMembersError(config, operation, results)
+}
+
+// callMembers calls the operation on all members concurrently, keeping their order.
+func callMembers(ctx context.Context, config *hazelcast.Config, addresses []string, operation, argument string) []memberResult {
+	results := make([]memberResult, len(addresses))
+	var wg sync.WaitGroup
+	for i, a := range addresses {
+		wg.Add(1)
+		go func(i int, a string) {
+			defer wg.Done()
+			results[i].member = a
+			body, err := connection.CallMemberOperation(ctx, config, a, operation, argument)
+			if err == nil {
+				_, err = connection.ParseRESTResponse(body)
+			}
+			results[i].err = err
+		}(i, a)
+	}
+	wg.Wait()
+	return results
+}
+
+func writeMemberResults(out io.Writer, results []memberResult) error {
+	tw := table.NewTableWriter(out)
+	if err := tw.WriteHeader("Member", "Result"); err != nil {
+		return err
+	}
+	for _, r := range results {
+		result := "accepted"
+		if r.err != nil {
+			result = r.err.Error()
+		}
+		if err := tw.Write(r.member, result); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// failedMembersError returns an error if a member did not accept the operation. The error of the first member which
+// did not accept it is explained if possible, e.g. if the REST API is not enabled.
+func failedMembersError(config *hazelcast.Config, operation string, results []memberResult) error {
+	var failed []memberResult
+	for _, r := range results {
+		if r.err != nil {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	err := failed[0].err
+	if msg, handled := hzcerrors.TranslateError(err, config.Cluster.Cloud.Enabled, operation); handled {
+		return hzcerrors.NewLoggableError(err, "%d of %d members did not accept the change.\n\n%s", len(failed), len(results), msg)
+	}
+	return hzcerrors.NewLoggableError(err, "%d of %d members did not accept the change", len(failed), len(results))
+}
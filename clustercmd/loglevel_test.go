@@ -0,0 +1,56 @@
+package clustercmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+func TestCallMembers(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	accepting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, r.URL.Path+"?"+string(body))
+		mu.Unlock()
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer accepting.Close()
+	forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"forbidden"}`))
+	}))
+	defer forbidden.Close()
+	var config hazelcast.Config
+	config.Cluster.Name = "dev"
+	addresses := []string{strings.TrimPrefix(accepting.URL, "http://"), strings.TrimPrefix(forbidden.URL, "http://")}
+	results := callMembers(context.Background(), &config, addresses, constants.LogLevelSet, "FINE")
+	require.Equal(t, []string{constants.LogLevelEndpoint + "?dev&&FINE"}, requests)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].err)
+	require.Error(t, results[1].err)
+	var b bytes.Buffer
+	require.NoError(t, writeMemberResults(&b, results))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Regexp(t, `^\| `+addresses[0]+` \| accepted +\|$`, lines[3])
+	require.Regexp(t, `^\| `+addresses[1]+` \| the cluster name or the password is not correct \|$`, lines[4])
+	require.EqualError(t, failedMembersError(&config, constants.LogLevelSet, results), "1 of 2 members did not accept the change")
+	require.NoError(t, failedMembersError(&config, constants.LogLevelSet, results[:1]))
+}
+
+func TestIsLogLevel(t *testing.T) {
+	require.True(t, isLogLevel("FINEST"))
+	require.False(t, isLogLevel("DEBUG"))
+}
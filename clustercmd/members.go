@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+)
+
+func NewMembers(config *hazelcast.Config) *cobra.Command {
+	var watch bool
+	cmd := &cobra.Command{
+		Use:   "members [--watch]",
+		Short: "List the members of the cluster",
+		Long: `List the members in the cluster view of the client with their UUID, address, version and whether they are lite members.
+Connected is whether the client has a connection to the member, it is only known if the CLC is built with the hazelcastinternal tag.
+With --watch, the members added to and removed from the cluster are written until the command is interrupted.`,
+		Example: `  cluster members
+  cluster members --watch`,
+		// unlike the other cluster operations, members does not use the REST API, so it works on cloud too
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := connection.ConnectToCluster(ctx, config)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Can not connect to the cluster")
+			}
+			members, err := connection.Members(ctx)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Can not get the members of the cluster")
+			}
+			if err := writeMembers(cmd.OutOrStdout(), members, connection.MemberConnected); err != nil {
+				return err
+			}
+			if !watch {
+				return nil
+			}
+			cmd.Println("Watching the membership changes, press Ctrl+C to stop")
+			if err := watchMembers(ctx, c, cmd.OutOrStdout()); err != nil {
+				return hzcerrors.NewLoggableError(err, "Can not watch the members of the cluster")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "write the members added to and removed from the cluster until interrupted")
+	return cmd
+}
+
+func writeMembers(out io.Writer, members []cluster.MemberInfo, connected func(types.UUID) (bool, bool)) error {
+	tw := table.NewTableWriter(out)
+	if err := tw.WriteHeader("UUID", "Address", "Version", "Lite Member", "Connected"); err != nil {
+		return err
+	}
+	for _, m := range members {
+		isConnected := "unknown"
+		if c, known := connected(m.UUID); known {
+			isConnected = strconv.FormatBool(c)
+		}
+		if err := tw.Write(m.UUID, m.Address, memberVersion(m.Version), m.LiteMember, isConnected); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func memberVersion(v cluster.MemberVersion) string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// discoverMembers connects to the cluster and returns its members. Half of the time left is given to the discovery,
+// so that the members can still be queried.
+func discoverMembers(ctx context.Context, config *hazelcast.Config) ([]cluster.MemberInfo, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Until(deadline)/2)
+		defer cancel()
+	}
+	if _, err := connection.ConnectToCluster(ctx, config); err != nil {
+		return nil, err
+	}
+	return connection.Members(ctx)
+}
+
+// memberAddresses returns the addresses of the members if the client can connect to the cluster, otherwise the
+// configured addresses.
+func memberAddresses(ctx context.Context, config *hazelcast.Config) []string {
+	members, err := discoverMembers(ctx, config)
+	if err != nil {
+		return connection.RESTAddresses(config)
+	}
+	addresses := make([]string, len(members))
+	for i, m := range members {
+		addresses[i] = m.Address.String()
+	}
+	return addresses
+}
+
+// watchMembers writes the membership changes to out until ctx is canceled, e.g. on Ctrl+C.
+func watchMembers(ctx context.Context, c *hazelcast.Client, out io.Writer) error {
+	// the events are written by a single goroutine, so that the lines are not interleaved
+	events := make(chan cluster.MembershipStateChanged, 16)
+	id, err := c.AddMembershipListener(func(event cluster.MembershipStateChanged) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	})
+	if err != nil {
+		return err
+	}
+	defer c.RemoveMembershipListener(id)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e := <-events:
+			if _, err := fmt.Fprintln(out, membershipChangeText(time.Now(), e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func membershipChangeText(t time.Time, e cluster.MembershipStateChanged) string {
+	return fmt.Sprintf("%s %-7s %s %s (version %s, lite member: %t)", t.Format(time.RFC3339), e.State, e.Member.UUID, e.Member.Address, memberVersion(e.Member.Version), e.Member.LiteMember)
+}
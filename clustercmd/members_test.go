@@ -0,0 +1,50 @@
+package clustercmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/require"
+)
+
+var testMembers = []cluster.MemberInfo{
+	{
+		UUID:    types.NewUUIDWith(1, 1),
+		Address: "10.0.0.1:5701",
+		Version: cluster.MemberVersion{Major: 5, Minor: 1, Patch: 2},
+	},
+	{
+		UUID:       types.NewUUIDWith(2, 2),
+		Address:    "127.0.0.1:5702",
+		Version:    cluster.MemberVersion{Major: 5, Minor: 2},
+		LiteMember: true,
+	},
+}
+
+func TestWriteMembers(t *testing.T) {
+	var b bytes.Buffer
+	connected := func(uuid types.UUID) (bool, bool) {
+		return uuid == testMembers[1].UUID, true
+	}
+	require.NoError(t, writeMembers(&b, testMembers, connected))
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	require.Len(t, lines, 5)
+	require.Regexp(t, `^\| 00000000-0000-0001-0000-000000000001 \| 10.0.0.1:5701 +\| 5.1.2 +\| false +\| false +\|$`, lines[3])
+	require.Regexp(t, `^\| 00000000-0000-0002-0000-000000000002 \| 127.0.0.1:5702 \| 5.2.0 +\| true +\| true +\|$`, lines[4])
+	b.Reset()
+	unknown := func(types.UUID) (bool, bool) {
+		return false, false
+	}
+	require.NoError(t, writeMembers(&b, testMembers, unknown))
+	require.Contains(t, b.String(), "| unknown")
+}
+
+func TestMembershipChangeText(t *testing.T) {
+	ts := time.Date(2022, 10, 1, 12, 30, 0, 0, time.UTC)
+	e := cluster.MembershipStateChanged{State: cluster.MembershipStateRemoved, Member: testMembers[1]}
+	require.Equal(t, "2022-10-01T12:30:00Z removed 00000000-0000-0002-0000-000000000002 127.0.0.1:5702 (version 5.2.0, lite member: true)", membershipChangeText(ts, e))
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+const (
+	outputPretty = "pretty"
+	outputJSON   = "json"
+)
+
+var outputTypes = []string{outputPretty, outputJSON}
+
+func addOutputTypeFlag(cmd *cobra.Command, outputType *string) {
+	cmd.Flags().StringVarP(outputType, "output-type", "o", outputPretty, fmt.Sprintf("%s or %s", outputPretty, outputJSON))
+	cmd.RegisterFlagCompletionFunc("output-type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return outputTypes, cobra.ShellCompDirectiveDefault
+	})
+}
+
+func validateOutputType(outputType string) error {
+	if outputType != outputPretty && outputType != outputJSON {
+		return hzcerrors.NewLoggableError(nil, "Invalid output type %q, it should be one of %s, %s", outputType, outputPretty, outputJSON)
+	}
+	return nil
+}
+
+// writeJSON writes v as indented JSON.
+func writeJSON(out io.Writer, v interface{}) error {
+	e := json.NewEncoder(out)
+	e.SetIndent("", "  ")
+	return e.Encode(v)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"fmt"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+func NewPersistence(config *hazelcast.Config) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "persistence {force-start | partial-start | backup | backup-interrupt}",
+		Short: "Persistence administration",
+		Long: `Administrative operations for clusters with persistence, which must be enabled on the members together with the PERSISTENCE endpoint group of the REST API.
+The operations which delete persisted data ask for confirmation, unless --yes is given, and fail if it is declined.`,
+		DisableFlagParsing: true,
+		RunE:               hzcerrors.RootRunnerFnc,
+	}
+	subCmds := []struct {
+		command string
+		info    string
+		// question is asked for confirmation before the operations which delete data
+		question string
+	}{
+		{
+			command:  constants.PersistenceForceStart,
+			info:     "force the cluster to start, the members delete their persisted data and start empty",
+			question: "Force start the cluster %q? All members delete their persisted data.",
+		},
+		{
+			command:  constants.PersistencePartialStart,
+			info:     "start the cluster with the members which could restore their persisted data",
+			question: "Partially start the cluster %q? The persisted data of the members which are left out is deleted.",
+		},
+		{
+			command: constants.PersistenceBackup,
+			info:    "back up the persisted data of all members",
+		},
+		{
+			command: constants.PersistenceBackupInterrupt,
+			info:    "interrupt the running backup",
+		},
+	}
+	for _, sc := range subCmds {
+		// copy to use it in the inner func
+		sc := sc
+		var yes bool
+		c := &cobra.Command{
+			Use:     sc.command,
+			Short:   sc.info,
+			PreRunE: hzcerrors.RequiredFlagChecker,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				defer hzcerrors.ErrorRecover(cmd.ErrOrStderr())
+				if sc.question != "" && !yes {
+					ok, err := confirm(cmd, fmt.Sprintf(sc.question, config.Cluster.Name))
+					if err != nil {
+						return err
+					}
+					if !ok {
+						return hzcerrors.NewLoggableError(nil, "Canceled, the persisted data is kept")
+					}
+				}
+				result, err := connection.CallClusterOperation(cmd.Context(), config, sc.command)
+				if err != nil {
+					return err
+				}
+				text, err := describeResponse(sc.command, result.Body)
+				if err != nil {
+					return err
+				}
+				cmd.Println(text)
+				printAnsweringMember(cmd, result)
+				return nil
+			},
+		}
+		if sc.question != "" {
+			c.Flags().BoolVarP(&yes, "yes", "y", false, "run without asking for confirmation")
+		}
+		cmd.AddCommand(c)
+	}
+	return &cmd
+}
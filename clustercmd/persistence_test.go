@@ -0,0 +1,77 @@
+package clustercmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+func TestPersistence(t *testing.T) {
+	fakeTerminal(t)
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.URL.Path+"?"+string(body))
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer s.Close()
+	var config hazelcast.Config
+	config.Cluster.Name = "dev"
+	config.Cluster.Network.SetAddresses(strings.TrimPrefix(s.URL, "http://"))
+	tcs := []struct {
+		args     []string
+		input    string
+		requests []string
+		output   string
+		err      string
+	}{
+		{
+			args:     []string{"backup"},
+			requests: []string{constants.BackupEndpoint + "?dev&"},
+			output:   "Started the backup of the persisted data",
+		},
+		{
+			args:     []string{"force-start"},
+			input:    "y\n",
+			requests: []string{constants.ForceStartEndpoint + "?dev&"},
+			output:   "Requested the force start of the cluster",
+		},
+		{
+			args:   []string{"force-start"},
+			input:  "n\n",
+			output: `Force start the cluster "dev"?`,
+			err:    "Canceled, the persisted data is kept",
+		},
+		{
+			args:     []string{"partial-start", "--yes"},
+			requests: []string{constants.PartialStartEndpoint + "?dev&"},
+			output:   "Requested the partial start of the cluster",
+		},
+	}
+	for _, tc := range tcs {
+		requests = nil
+		cmd := NewPersistence(&config)
+		var out bytes.Buffer
+		cmd.SetArgs(tc.args)
+		cmd.SetIn(strings.NewReader(tc.input))
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		if err := cmd.Execute(); tc.err != "" {
+			require.EqualError(t, err, tc.err)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, tc.requests, requests, tc.args)
+		require.Contains(t, out.String(), tc.output)
+	}
+}
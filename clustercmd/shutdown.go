@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+func NewShutdown(config *hazelcast.Config) *cobra.Command {
+	var (
+		yes     bool
+		dryRun  bool
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "shutdown [--yes] [--dry-run]",
+		Short: "shuts down the cluster",
+		Long: `Shut down all members of the cluster after asking for confirmation, which shows the name of the cluster and its members.
+Use "member shutdown" to shut down a single member instead.`,
+		Example: `  cluster shutdown --dry-run
+  cluster shutdown --yes`,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer hzcerrors.ErrorRecover(cmd.ErrOrStderr())
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			members, err := discoverMembers(ctx, config)
+			target := shutdownTarget(config.Cluster.Name, members, err)
+			if dryRun {
+				cmd.Printf("Would shut down %s\n", target)
+				return nil
+			}
+			if !yes {
+				ok, err := confirm(cmd, fmt.Sprintf("Shut down %s?", target))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return hzcerrors.NewLoggableError(nil, "Canceled, the cluster is not shut down")
+				}
+			}
+			result, err := connection.CallClusterOperation(cmd.Context(), config, constants.ClusterShutdown)
+			if err != nil {
+				return err
+			}
+			text, err := describeResponse(constants.ClusterShutdown, result.Body)
+			if err != nil {
+				return err
+			}
+			cmd.Println(text)
+			printAnsweringMember(cmd, result)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "shut down without asking for confirmation")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the cluster which would be shut down without shutting it down")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "time to discover the members")
+	return cmd
+}
+
+// shutdownTarget describes the cluster which is shut down, err is the error of the member discovery.
+func shutdownTarget(name string, members []cluster.MemberInfo, err error) string {
+	if err != nil {
+		return fmt.Sprintf("the cluster %q (the members are unknown: %s)", name, err)
+	}
+	addresses := make([]string, len(members))
+	for i, m := range members {
+		addresses[i] = m.Address.String()
+	}
+	noun := "members"
+	if len(members) == 1 {
+		noun = "member"
+	}
+	return fmt.Sprintf("the cluster %q with %d %s (%s)", name, len(members), noun, strings.Join(addresses, ", "))
+}
+
+func NewMember(config *hazelcast.Config) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "member {shutdown}",
+		Short: "Administrative member operations",
+		Long:  `Administrative operations on a single member of the cluster, e.g. for rolling maintenance`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if config.Cluster.Cloud.Enabled {
+				return hzcerrors.NewLoggableError(nil, invocationOnCloudInfoMessage)
+			}
+			return nil
+		},
+		DisableFlagParsing: true,
+		RunE:               hzcerrors.RootRunnerFnc,
+	}
+	cmd.AddCommand(NewMemberShutdown(config))
+	return &cmd
+}
+
+func NewMemberShutdown(config *hazelcast.Config) *cobra.Command {
+	var (
+		member string
+		yes    bool
+	)
+	cmd := &cobra.Command{
+		Use:   "shutdown --member member-address [--yes]",
+		Short: "gracefully shut down a member",
+		Long: `Gracefully shut down the member with the given address, its data is migrated to the other members first.
+The REST API must be enabled on the member with the CLUSTER_WRITE endpoint group.
+The member is given with --member, since --address is the address that the CLC connects to.`,
+		Example: `  member shutdown --member 192.168.1.2:5701`,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer hzcerrors.ErrorRecover(cmd.ErrOrStderr())
+			if !yes {
+				ok, err := confirm(cmd, fmt.Sprintf("Shut down the member %s of the cluster %q?", member, config.Cluster.Name))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return hzcerrors.NewLoggableError(nil, "Canceled, the member is not shut down")
+				}
+			}
+			body, err := connection.CallMemberOperation(cmd.Context(), config, member, constants.MemberShutdown, "")
+			if err != nil {
+				if msg, handled := hzcerrors.TranslateError(err, config.Cluster.Cloud.Enabled, constants.MemberShutdown); handled {
+					return hzcerrors.NewLoggableError(err, msg)
+				}
+				return err
+			}
+			text, err := describeResponse(constants.MemberShutdown, body)
+			if err != nil {
+				return err
+			}
+			cmd.Println(text)
+			return nil
+		},
+	}
+	// the address flag of the root command is the address to connect to, so the member to shut down has its own flag
+	cmd.Flags().StringVarP(&member, "member", "m", "", "address of the member to shut down, e.g. 192.168.1.2:5701")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "shut down without asking for confirmation")
+	cmd.MarkFlagRequired("member")
+	return cmd
+}
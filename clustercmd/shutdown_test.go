@@ -0,0 +1,73 @@
+package clustercmd
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+func TestShutdownTarget(t *testing.T) {
+	require.Equal(t, `the cluster "dev" with 2 members (10.0.0.1:5701, 127.0.0.1:5702)`, shutdownTarget("dev", testMembers, nil))
+	require.Equal(t, `the cluster "dev" with 1 member (10.0.0.1:5701)`, shutdownTarget("dev", testMembers[:1], nil))
+	require.Equal(t, `the cluster "dev" (the members are unknown: timeout)`, shutdownTarget("dev", nil, errors.New("timeout")))
+}
+
+func TestMemberShutdown(t *testing.T) {
+	fakeTerminal(t)
+	var requests []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.URL.Path+"?"+string(body))
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer s.Close()
+	var config hazelcast.Config
+	config.Cluster.Name = "dev"
+	address := strings.TrimPrefix(s.URL, "http://")
+	tcs := []struct {
+		args     []string
+		input    string
+		requests []string
+		output   string
+		err      string
+	}{
+		{
+			args:   []string{"shutdown", "--member", address},
+			input:  "n\n",
+			output: `Shut down the member ` + address + ` of the cluster "dev"? [y/N]`,
+			err:    "Canceled, the member is not shut down",
+		},
+		{
+			args:     []string{"shutdown", "--member", address, "--yes"},
+			requests: []string{constants.MemberShutdownEndpoint + "?dev&"},
+			output:   "The member is shutting down",
+		},
+	}
+	for _, tc := range tcs {
+		requests = nil
+		cmd := NewMember(&config)
+		var out bytes.Buffer
+		cmd.SetArgs(tc.args)
+		cmd.SetIn(strings.NewReader(tc.input))
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		if err := cmd.Execute(); tc.err != "" {
+			require.EqualError(t, err, tc.err)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, tc.requests, requests)
+		require.Equal(t, tc.output, strings.TrimSpace(out.String()))
+	}
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package clustercmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+// statePollInterval is the period of the state checks while waiting for the new state.
+const statePollInterval = time.Second
+
+var states = []string{"active", "no_migration", "frozen", "passive"}
+
+func NewChangeState(config *hazelcast.Config) *cobra.Command {
+	// monitored flag variables
+	var (
+		newState string
+		yes      bool
+		wait     bool
+		timeout  time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("change-state [--state [%s]] [--yes] [--wait [--timeout duration]]", strings.Join(states, ",")),
+		Short: "Change state of the cluster",
+		Example: `  cluster change-state --state frozen
+  cluster change-state --state active --yes --wait --timeout 2m`,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			defer hzcerrors.ErrorRecover(cmd.ErrOrStderr())
+			if !connection.ValidateState(newState) {
+				return hzcerrors.NewLoggableError(connection.InvalidStateErr, "Invalid new state. It should be one the following: %s", strings.Join(states, ", "))
+			}
+			if !yes {
+				ok, err := confirm(cmd, fmt.Sprintf("Change the state of the cluster %q to %s?", config.Cluster.Name, newState))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return hzcerrors.NewLoggableError(nil, "Canceled, the cluster state is not changed")
+				}
+			}
+			result, err := connection.CallClusterOperationWithState(cmd.Context(), config, constants.ClusterChangeState, &newState)
+			if err != nil {
+				return err
+			}
+			text, err := describeResponse(constants.ClusterChangeState, result.Body)
+			if err != nil {
+				return err
+			}
+			cmd.Println(text)
+			printAnsweringMember(cmd, result)
+			if !wait {
+				return nil
+			}
+			ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+			defer cancel()
+			if err := waitForState(ctx, config, memberAddresses(ctx, config), newState); err != nil {
+				if errors.Is(ctx.Err(), context.Canceled) {
+					return hzcerrors.NewLoggableError(err, "Canceled waiting for the new state: %s", err)
+				}
+				return hzcerrors.NewLoggableError(err, "Timed out waiting for the new state: %s", err)
+			}
+			cmd.Printf("All members report the state %s\n", newState)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&newState, "state", "s", "", fmt.Sprintf("new state of the cluster: %s", strings.Join(states, ",")))
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "change the state without asking for confirmation")
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until every member reports the new state")
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Minute, "time to wait for the new state [--wait]")
+	cmd.MarkFlagRequired("state")
+	cmd.RegisterFlagCompletionFunc("state", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return states, cobra.ShellCompDirectiveDefault
+	})
+	return cmd
+}
+
+// isTerminal reports whether in is a terminal.
+var isTerminal = func(in io.Reader) bool {
+	f, ok := in.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// confirm asks the question and reports whether the answer read from the input is yes. It fails without asking if
+// the input is not a terminal, so that a script does not take the end of its input as the answer.
+func confirm(cmd *cobra.Command, question string) (bool, error) {
+	if !isTerminal(cmd.InOrStdin()) {
+		return false, hzcerrors.NewLoggableError(nil, "Cannot ask for confirmation since the input is not a terminal, use --yes to confirm")
+	}
+	cmd.PrintErrf("%s [y/N] ", question)
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
+
+// waitForState queries the state of the members until all of them report state, or ctx is done.
+func waitForState(ctx context.Context, config *hazelcast.Config, addresses []string, state string) error {
+	ticker := time.NewTicker(statePollInterval)
+	defer ticker.Stop()
+	for {
+		pending := pendingMembers(ctx, config, addresses, state)
+		if len(pending) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("members not in state %s: %s", state, strings.Join(pending, ", "))
+		case <-ticker.C:
+		}
+	}
+}
+
+// pendingMembers returns the members which do not report state, with their current state or the error.
+func pendingMembers(ctx context.Context, config *hazelcast.Config, addresses []string, state string) []string {
+	var pending []string
+	for _, a := range addresses {
+		body, err := connection.CallMemberOperation(ctx, config, a, constants.ClusterGetState, "")
+		if err != nil {
+			pending = append(pending, fmt.Sprintf("%s (%s)", a, err))
+			continue
+		}
+		r, err := connection.ParseRESTResponse(body)
+		if err != nil {
+			pending = append(pending, fmt.Sprintf("%s (%s)", a, err))
+			continue
+		}
+		if !strings.EqualFold(r.State, state) {
+			pending = append(pending, fmt.Sprintf("%s (%s)", a, r.State))
+		}
+	}
+	return pending
+}
@@ -0,0 +1,108 @@
+package clustercmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+// stateServer reports the active state for the given number of requests, then the frozen state.
+func stateServer(t *testing.T, activeRequests int32) string {
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "FROZEN"
+		if atomic.AddInt32(&requests, 1) <= activeRequests {
+			state = "ACTIVE"
+		}
+		w.Write([]byte(`{"status":"success","state":"` + state + `"}`))
+	}))
+	t.Cleanup(s.Close)
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func TestWaitForState(t *testing.T) {
+	var config hazelcast.Config
+	addresses := []string{stateServer(t, 0), stateServer(t, 1)}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, waitForState(ctx, &config, addresses, "frozen"))
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := waitForState(ctx, &config, append(addresses, stateServer(t, 100)), "frozen")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "(ACTIVE)")
+}
+
+// fakeTerminal makes the commands ask for confirmation on the input of the test.
+func fakeTerminal(t *testing.T) {
+	isTerminalFn := isTerminal
+	isTerminal = func(io.Reader) bool {
+		return true
+	}
+	t.Cleanup(func() {
+		isTerminal = isTerminalFn
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	fakeTerminal(t)
+	tcs := []struct {
+		input string
+		ok    bool
+	}{
+		{input: "y\n", ok: true},
+		{input: " Yes\n", ok: true},
+		{input: "n\n"},
+		{input: "\n"},
+		{input: ""},
+	}
+	for _, tc := range tcs {
+		var cmd cobra.Command
+		var out bytes.Buffer
+		cmd.SetIn(strings.NewReader(tc.input))
+		cmd.SetErr(&out)
+		ok, err := confirm(&cmd, "Change?")
+		require.NoError(t, err)
+		require.Equal(t, tc.ok, ok, tc.input)
+		require.Equal(t, "Change? [y/N] ", out.String())
+	}
+}
+
+func TestConfirm_NotTerminal(t *testing.T) {
+	var cmd cobra.Command
+	var out bytes.Buffer
+	cmd.SetIn(strings.NewReader("y\n"))
+	cmd.SetErr(&out)
+	_, err := confirm(&cmd, "Change?")
+	require.EqualError(t, err, "Cannot ask for confirmation since the input is not a terminal, use --yes to confirm")
+	require.Empty(t, out.String())
+}
+
+func TestDescribeResponse(t *testing.T) {
+	text, err := describeResponse(constants.ClusterGetState, `{"status":"success","state":"active"}`)
+	require.NoError(t, err)
+	require.Equal(t, "The cluster state is active", text)
+	text, err = describeResponse(constants.ClusterVersion, `{"status":"success","version":"5.1"}`)
+	require.NoError(t, err)
+	require.Equal(t, "The cluster version is 5.1", text)
+	_, err = describeResponse(constants.ClusterChangeState, `{"status":"forbidden"}`)
+	require.EqualError(t, err, "The cluster could not change the state: the cluster name or the password is not correct")
+	_, err = describeResponse(constants.ClusterChangeState, `{"status":"fail","message":"State is already FROZEN"}`)
+	require.EqualError(t, err, "The cluster could not change the state: fail: State is already FROZEN")
+	// the responses which are not JSON are written as they are
+	text, err = describeResponse(constants.ClusterGetState, "active")
+	require.NoError(t, err)
+	require.Equal(t, "active", text)
+}
@@ -36,6 +36,10 @@ import (
 )
 
 const defaultConfigFilename = "config.yaml"
+
+// OptionalConfigAnnotation marks the commands which run even if the configuration file can not be read,
+// e.g. to validate or fix it.
+const OptionalConfigAnnotation = "hzc-optional-config"
 const (
 	DefaultClusterAddress  = "localhost:5701"
 	DefaultClusterName     = "dev"
@@ -58,6 +62,8 @@ type Config struct {
 	NoAutocompletion bool
 	Styling          Styling
 	Logger           Logger
+	// Profile is the name of the profile applied to the configuration, blank if no profile is used
+	Profile string `yaml:"-"`
 }
 
 type Logger struct {
@@ -79,6 +85,7 @@ type GlobalFlagValues struct {
 	NoColor          bool
 	LogFile          string
 	LogLevel         string
+	Profile          string
 }
 
 func DefaultConfig() Config {
@@ -162,7 +169,14 @@ func WriteToFile(config *Config, confPath string) error {
 
 func ReadAndMergeWithFlags(flags *GlobalFlagValues, c *Config) error {
 	p := DefaultConfigPath()
-	if err := readConfig(flags.CfgFile, c, p); err != nil {
+	profile := flags.Profile
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	if err := readConfig(flags.CfgFile, profile, c, p); err != nil {
+		return err
+	}
+	if err := mergeEnvWithConfig(c); err != nil {
 		return err
 	}
 	setStyling(flags.NoColor, c)
@@ -227,9 +241,7 @@ func mergeFlagsWithConfig(flags *GlobalFlagValues, config *Config) error {
 	if err := updateConfigWithSSL(&config.Hazelcast, &config.SSL); err != nil {
 		return hzcerrors.NewLoggableError(err, "can not configure ssl")
 	}
-	addrRaw := flags.Address
-	if addrRaw != "" {
-		addresses := strings.Split(strings.TrimSpace(addrRaw), ",")
+	if addresses := splitAddresses(flags.Address); len(addresses) > 0 {
 		config.Hazelcast.Cluster.Network.Addresses = addresses
 	}
 	if flags.Cluster != "" {
@@ -259,7 +271,9 @@ func mergeFlagsWithConfig(flags *GlobalFlagValues, config *Config) error {
 	return nil
 }
 
-func readConfig(path string, config *Config, defaultConfPath string) error {
+// readConfig reads the configuration file and applies the given profile, or the default profile of the file if the
+// profile is blank.
+func readConfig(path, profile string, config *Config, defaultConfPath string) error {
 	var confBytes []byte
 	var err error
 	exists, err := file.Exists(path)
@@ -282,7 +296,7 @@ func readConfig(path string, config *Config, defaultConfPath string) error {
 	if err = yaml.Unmarshal(confBytes, config); err != nil {
 		return hzcerrors.NewLoggableError(err, "%s is not valid YAML", path)
 	}
-	return nil
+	return applyProfile(confBytes, profile, config, path)
 }
 
 func DefaultConfigPath() string {
@@ -335,3 +349,14 @@ func GetClusterAddress(c *hazelcast.Config) string {
 	}
 	return address
 }
+
+// splitAddresses splits the comma separated addresses, trimming the spaces around them and dropping the blank ones.
+func splitAddresses(s string) []string {
+	var addresses []string
+	for _, a := range strings.Split(s, ",") {
+		if a = strings.TrimSpace(a); a != "" {
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses
+}
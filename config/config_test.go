@@ -83,7 +83,7 @@ func TestReadConfig(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			cfg := DefaultConfig()
-			err := readConfig(tc.path, &cfg, tc.defaultConfigPath)
+			err := readConfig(tc.path, "", &cfg, tc.defaultConfigPath)
 			if tc.errMsg != "" {
 				require.NotNil(t, err)
 				require.Equal(t, err.Error(), tc.errMsg)
@@ -205,7 +205,7 @@ func TestSetStyling(t *testing.T) {
 func TestDefaultConfigWritten(t *testing.T) {
 	path := uniquePath(t.TempDir())
 	cfg := DefaultConfig()
-	err := readConfig(path, &cfg, path)
+	err := readConfig(path, "", &cfg, path)
 	if err != nil {
 		var le clcerrors.LoggableError
 		if errors.As(err, &le) {
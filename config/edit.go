@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"os"
+	"strings"
+
+	yaml3 "gopkg.in/yaml.v3"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+)
+
+// GetValue returns the value of the key in the configuration file, the key is the dot separated path of the YAML
+// keys, e.g. hazelcast.cluster.name. Mappings and sequences are returned as YAML.
+func GetValue(path, key string) (string, error) {
+	root, _, err := readDocument(path)
+	if err != nil {
+		return "", err
+	}
+	n := lookupPath(root, splitKey(key))
+	if n == nil {
+		return "", hzcerrors.NewLoggableError(nil, "%s is not set in %s", key, path)
+	}
+	if n.Kind == yaml3.ScalarNode {
+		return n.Value, nil
+	}
+	b, err := encodeNode(n)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
+
+// SetValue sets the value of the key in the configuration file, the missing parent keys are added. The value is
+// parsed as YAML, e.g. "[a, b]" is a sequence. The file is not changed if the key is not a configuration key or the
+// value is not valid for it. The rest of the file, including the comments, is kept.
+func SetValue(path, key, value string) error {
+	root, doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	keys := splitKey(key)
+	if len(keys) == 0 {
+		return hzcerrors.NewLoggableError(nil, "the key should not be blank")
+	}
+	var v yaml3.Node
+	if err = yaml3.Unmarshal([]byte(value), &v); err != nil {
+		return hzcerrors.NewLoggableError(err, "%q is not a valid value: %s", value, err)
+	}
+	n := &yaml3.Node{Kind: yaml3.ScalarNode, Tag: "!!str", Style: yaml3.DoubleQuotedStyle}
+	if len(v.Content) > 0 {
+		n = v.Content[0]
+	}
+	m := root
+	for i, k := range keys[:len(keys)-1] {
+		next := resolveAlias(mappingValue(m, k))
+		if next == nil || isNull(next) {
+			next = &yaml3.Node{Kind: yaml3.MappingNode}
+			setMappingValue(m, k, next)
+		}
+		if next.Kind != yaml3.MappingNode {
+			return hzcerrors.NewLoggableError(nil, "%s is not a mapping", strings.Join(keys[:i+1], "."))
+		}
+		m = next
+	}
+	last := keys[len(keys)-1]
+	if old := mappingValue(m, last); old != nil {
+		// keep the comments of the replaced value
+		n.HeadComment, n.LineComment, n.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	}
+	setMappingValue(m, last, n)
+	for _, p := range validateDocument(root) {
+		// the problems of the key, its values and the parent keys which are added
+		if p.Key == key || strings.HasPrefix(p.Key, key+".") || strings.HasPrefix(p.Key, key+"[") || strings.HasPrefix(key, p.Key+".") {
+			return hzcerrors.NewLoggableError(nil, "%s: %s", p.Key, p.Message)
+		}
+	}
+	b, err := encodeNode(doc)
+	if err != nil {
+		return err
+	}
+	return file.CreateMissingDirsAndFileWithRWPerms(path, b)
+}
+
+func readDocument(path string) (*yaml3.Node, *yaml3.Node, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, hzcerrors.NewLoggableError(err, "cannot read configuration file on %s", path)
+	}
+	var doc yaml3.Node
+	if err = yaml3.Unmarshal(b, &doc); err != nil {
+		return nil, nil, hzcerrors.NewLoggableError(err, "%s is not valid YAML: %s", path, err)
+	}
+	root, err := documentMapping(&doc)
+	if err != nil {
+		return nil, nil, hzcerrors.NewLoggableError(err, "%s is not a valid configuration: %s", path, err)
+	}
+	return root, &doc, nil
+}
+
+func splitKey(key string) []string {
+	if key = strings.TrimSpace(key); key == "" {
+		return nil
+	}
+	return strings.Split(key, ".")
+}
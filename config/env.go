@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/logger"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+// EnvProfile selects the profile if the profile flag is not given.
+const EnvProfile = "CLC_PROFILE"
+
+/*
+envVars are the environment variables which override the configuration file. The flags override them, so the
+precedence from the lowest to the highest is:
+ 1. defaults
+ 2. configuration file, the selected profile overrides the rest of the file
+ 3. environment variables
+ 4. flags
+
+The blank environment variables are ignored. keys are the configuration keys set by the variable.
+*/
+var envVars = []struct {
+	name  string
+	keys  []string
+	apply func(c *Config, v string) error
+}{
+	{
+		name: "CLC_ADDRESS",
+		keys: []string{"hazelcast.cluster.network.addresses"},
+		apply: func(c *Config, v string) error {
+			if addresses := splitAddresses(v); len(addresses) > 0 {
+				c.Hazelcast.Cluster.Network.Addresses = addresses
+			}
+			return nil
+		},
+	},
+	{
+		name: "CLC_CLUSTER_NAME",
+		keys: []string{"hazelcast.cluster.name"},
+		apply: func(c *Config, v string) error {
+			c.Hazelcast.Cluster.Name = strings.TrimSpace(v)
+			return nil
+		},
+	},
+	{
+		name: "CLC_VIRIDIAN_TOKEN",
+		keys: []string{"hazelcast.cluster.cloud.token", "hazelcast.cluster.cloud.enabled"},
+		apply: func(c *Config, v string) error {
+			c.Hazelcast.Cluster.Cloud.Token = strings.TrimSpace(v)
+			c.Hazelcast.Cluster.Cloud.Enabled = true
+			return nil
+		},
+	},
+	{
+		name: "CLC_CLUSTER_USERNAME",
+		keys: []string{"hazelcast.cluster.security.credentials.username"},
+		apply: func(c *Config, v string) error {
+			c.Hazelcast.Cluster.Security.Credentials.Username = v
+			return nil
+		},
+	},
+	{
+		name: "CLC_CLUSTER_PASSWORD",
+		keys: []string{"hazelcast.cluster.security.credentials.password"},
+		apply: func(c *Config, v string) error {
+			c.Hazelcast.Cluster.Security.Credentials.Password = v
+			return nil
+		},
+	},
+	{
+		name: "CLC_SSL_ENABLED",
+		keys: []string{"ssl.enabled"},
+		apply: func(c *Config, v string) error {
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid value (%s) of CLC_SSL_ENABLED, should be true or false", v)
+			}
+			c.SSL.Enabled = enabled
+			return nil
+		},
+	},
+	{
+		name: "CLC_SSL_SERVER_NAME",
+		keys: []string{"ssl.servername"},
+		apply: func(c *Config, v string) error {
+			c.SSL.ServerName = v
+			return nil
+		},
+	},
+	{
+		name: "CLC_SSL_CA_PATH",
+		keys: []string{"ssl.capath"},
+		apply: func(c *Config, v string) error {
+			c.SSL.CAPath = v
+			return nil
+		},
+	},
+	{
+		name: "CLC_SSL_CERT_PATH",
+		keys: []string{"ssl.certpath"},
+		apply: func(c *Config, v string) error {
+			c.SSL.CertPath = v
+			return nil
+		},
+	},
+	{
+		name: "CLC_SSL_KEY_PATH",
+		keys: []string{"ssl.keypath"},
+		apply: func(c *Config, v string) error {
+			c.SSL.KeyPath = v
+			return nil
+		},
+	},
+	{
+		name: "CLC_SSL_KEY_PASSWORD",
+		keys: []string{"ssl.keypassword"},
+		apply: func(c *Config, v string) error {
+			c.SSL.KeyPassword = v
+			return nil
+		},
+	},
+	{
+		name: "CLC_LOG_LEVEL",
+		keys: []string{"hazelcast.logger.level"},
+		apply: func(c *Config, v string) error {
+			if _, err := logger.WeightForLogLevel(logger.Level(v)); err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid log level (%s) of CLC_LOG_LEVEL, should be one of %s", v, ValidLogLevels)
+			}
+			c.Hazelcast.Logger.Level = logger.Level(v)
+			return nil
+		},
+	},
+	{
+		name: "CLC_LOG_FILE",
+		keys: []string{"logger.logfile"},
+		apply: func(c *Config, v string) error {
+			c.Logger.LogFile = v
+			return nil
+		},
+	},
+}
+
+// mergeEnvWithConfig overrides the configuration with the environment variables which are set.
+func mergeEnvWithConfig(config *Config) error {
+	for _, e := range envVars {
+		v := os.Getenv(e.name)
+		if v == "" {
+			continue
+		}
+		if err := e.apply(config, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// envVarOf returns the environment variable which sets the configuration key, blank if it is not set by one.
+func envVarOf(key string) string {
+	for _, e := range envVars {
+		if os.Getenv(e.name) == "" {
+			continue
+		}
+		for _, k := range e.keys {
+			if k == key {
+				return e.name
+			}
+		}
+	}
+	return ""
+}
+
+// EnvVarNames returns the names of the environment variables which override the configuration.
+func EnvVarNames() []string {
+	names := []string{EnvProfile}
+	for _, e := range envVars {
+		names = append(names, e.name)
+	}
+	return names
+}
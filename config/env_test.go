@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAndMergeWithFlags_Env(t *testing.T) {
+	path := uniquePathWithContent(t.TempDir(), []byte(profilesConfig))
+	testCases := []struct {
+		name         string
+		env          map[string]string
+		flags        GlobalFlagValues
+		expectName   string
+		expectAddrs  []string
+		expectToken  string
+		expectCloud  bool
+		expectCAPath string
+		expectLevel  logger.Level
+		errMsg       string
+	}{
+		{
+			name:        "Env: none, Expect: default profile",
+			expectName:  "staging",
+			expectAddrs: []string{"10.0.0.1:5701"},
+			expectLevel: logger.ErrorLevel,
+		},
+		{
+			name: "Env: set, Expect: env overrides the file",
+			env: map[string]string{
+				"CLC_ADDRESS":        "10.0.0.2:5701, 10.0.0.3:5701,",
+				"CLC_CLUSTER_NAME":   "ci",
+				"CLC_VIRIDIAN_TOKEN": "token",
+				"CLC_SSL_CA_PATH":    "ca.pem",
+				"CLC_LOG_LEVEL":      "info",
+			},
+			expectName:   "ci",
+			expectAddrs:  []string{"10.0.0.2:5701", "10.0.0.3:5701"},
+			expectToken:  "token",
+			expectCloud:  true,
+			expectCAPath: "ca.pem",
+			expectLevel:  logger.InfoLevel,
+		},
+		{
+			name:        "Env: set, Flags: set, Expect: flags override env",
+			env:         map[string]string{"CLC_ADDRESS": "10.0.0.2:5701", "CLC_CLUSTER_NAME": "ci", "CLC_LOG_LEVEL": "info"},
+			flags:       GlobalFlagValues{Address: "10.0.0.4:5701", Cluster: "flag", LogLevel: "warn"},
+			expectName:  "flag",
+			expectAddrs: []string{"10.0.0.4:5701"},
+			expectLevel: logger.WarnLevel,
+		},
+		{
+			name:        "Env: profile, Expect: profile is applied",
+			env:         map[string]string{EnvProfile: "prod"},
+			expectName:  "prod",
+			expectAddrs: []string{"localhost:5701"},
+			expectLevel: logger.ErrorLevel,
+		},
+		{
+			name:        "Env: profile, Flags: profile, Expect: flag selects the profile",
+			env:         map[string]string{EnvProfile: "prod"},
+			flags:       GlobalFlagValues{Profile: "staging"},
+			expectName:  "staging",
+			expectAddrs: []string{"10.0.0.1:5701"},
+			expectLevel: logger.ErrorLevel,
+		},
+		{
+			name:   "Env: invalid log level, Expect: error",
+			env:    map[string]string{"CLC_LOG_LEVEL": "loud"},
+			errMsg: fmt.Sprintf("Invalid log level (loud) of CLC_LOG_LEVEL, should be one of %s", ValidLogLevels),
+		},
+		{
+			name:   "Env: invalid ssl enabled, Expect: error",
+			env:    map[string]string{"CLC_SSL_ENABLED": "yes please"},
+			errMsg: "Invalid value (yes please) of CLC_SSL_ENABLED, should be true or false",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range EnvVarNames() {
+				t.Setenv(name, tc.env[name])
+			}
+			flags := tc.flags
+			flags.CfgFile = path
+			cfg := DefaultConfig()
+			err := ReadAndMergeWithFlags(&flags, &cfg)
+			if tc.errMsg != "" {
+				require.EqualError(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectName, cfg.Hazelcast.Cluster.Name)
+			require.Equal(t, tc.expectAddrs, cfg.Hazelcast.Cluster.Network.Addresses)
+			require.Equal(t, tc.expectToken, cfg.Hazelcast.Cluster.Cloud.Token)
+			require.Equal(t, tc.expectCloud, cfg.Hazelcast.Cluster.Cloud.Enabled)
+			require.Equal(t, tc.expectCAPath, cfg.SSL.CAPath)
+			require.Equal(t, tc.expectLevel, cfg.Hazelcast.Logger.Level)
+		})
+	}
+}
+
+func TestEffectiveSettings_Env(t *testing.T) {
+	path := uniquePathWithContent(t.TempDir(), []byte(profilesConfig))
+	for _, name := range EnvVarNames() {
+		t.Setenv(name, "")
+	}
+	t.Setenv("CLC_CLUSTER_NAME", "ci")
+	t.Setenv("CLC_CLUSTER_PASSWORD", "secret")
+	t.Setenv("CLC_ADDRESS", "10.0.0.2:5701")
+	settings, _, err := EffectiveSettings(&GlobalFlagValues{CfgFile: path, Address: "10.0.0.4:5701"})
+	require.NoError(t, err)
+	values := map[string]Setting{}
+	for _, s := range settings {
+		values[s.Key] = s
+	}
+	require.Equal(t, Setting{Key: "hazelcast.cluster.name", Value: "ci", Source: "env (CLC_CLUSTER_NAME)"}, values["hazelcast.cluster.name"])
+	require.Equal(t, Setting{Key: "hazelcast.cluster.security.credentials.password", Value: "<redacted>", Source: "env (CLC_CLUSTER_PASSWORD)"}, values["hazelcast.cluster.security.credentials.password"])
+	require.Equal(t, Setting{Key: "hazelcast.cluster.network.addresses", Value: "10.0.0.4:5701", Source: SourceFlag}, values["hazelcast.cluster.network.addresses"])
+}
@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+)
+
+// DefaultProfileName is the profile the connection wizard saves to if no profile name is given.
+const DefaultProfileName = "default"
+
+const (
+	profilesKey       = "profiles"
+	defaultProfileKey = "defaultprofile"
+)
+
+// profileTemplate holds the connection settings saved to a profile, a profile may contain any other top level
+// configuration as well.
+const profileTemplate = `hazelcast:
+  cluster:
+    name: {{ .Hazelcast.Cluster.Name}}
+    network:
+      addresses:
+      {{- range .Hazelcast.Cluster.Network.Addresses}}
+        - {{ . -}}
+      {{ else }}
+        - localhost:5701
+      {{- end }}
+    cloud:
+      token: "{{ .Hazelcast.Cluster.Cloud.Token}}"
+      enabled: {{ .Hazelcast.Cluster.Cloud.Enabled}}
+ssl:
+  enabled: {{ .SSL.Enabled}}
+  servername: "{{ .SSL.ServerName}}"
+  capath: "{{ .SSL.CAPath}}"
+  certpath: "{{ .SSL.CertPath}}"
+  keypath: "{{ .SSL.KeyPath}}"
+  keypassword: "{{ .SSL.KeyPassword}}"
+`
+
+// profiles is the part of the configuration file which holds the named profiles.
+type profiles struct {
+	DefaultProfile string                 `yaml:"defaultprofile"`
+	Profiles       map[string]interface{} `yaml:"profiles"`
+}
+
+func (p profiles) names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyProfile overrides the configuration with the given profile, or the default profile of the configuration file
+// if the name is blank. The values which are not in the profile are left as they are.
+func applyProfile(confBytes []byte, name string, config *Config, path string) error {
+	var p profiles
+	if err := yaml.Unmarshal(confBytes, &p); err != nil {
+		return hzcerrors.NewLoggableError(err, "%s is not valid YAML", path)
+	}
+	if name == "" {
+		name = p.DefaultProfile
+	}
+	if name == "" {
+		return nil
+	}
+	profile, ok := p.Profiles[name]
+	if !ok {
+		if len(p.Profiles) == 0 {
+			return hzcerrors.NewLoggableError(nil, "profile %q is not found, there are no profiles in %s", name, path)
+		}
+		return hzcerrors.NewLoggableError(nil, "profile %q is not found in %s, available profiles: %s", name, path, strings.Join(p.names(), ", "))
+	}
+	b, err := yaml.Marshal(profile)
+	if err != nil {
+		return hzcerrors.NewLoggableError(err, "profile %q in %s is not valid", name, path)
+	}
+	if err = yaml.Unmarshal(b, config); err != nil {
+		return hzcerrors.NewLoggableError(err, "profile %q in %s is not valid", name, path)
+	}
+	config.Profile = name
+	return nil
+}
+
+// ProfileNames returns the names of the profiles in the configuration file and the name of the default profile.
+// A missing configuration file has no profiles.
+func ProfileNames(path string) ([]string, string, error) {
+	p, err := readProfiles(path)
+	if err != nil {
+		return nil, "", err
+	}
+	return p.names(), p.DefaultProfile, nil
+}
+
+// ProfileExists returns true if the configuration file has a profile with the given name.
+func ProfileExists(path, name string) (bool, error) {
+	p, err := readProfiles(path)
+	if err != nil {
+		return false, err
+	}
+	_, ok := p.Profiles[name]
+	return ok, nil
+}
+
+func readProfiles(path string) (profiles, error) {
+	var p profiles
+	exists, err := file.Exists(path)
+	if err != nil || !exists {
+		return p, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return p, err
+	}
+	if err = yaml.Unmarshal(b, &p); err != nil {
+		return p, hzcerrors.NewLoggableError(err, "%s is not valid YAML", path)
+	}
+	return p, nil
+}
+
+// SaveProfile saves the connection settings of the configuration as the named profile, replacing the profile with
+// the same name. The rest of the configuration file, including the comments, is kept. The configuration file is
+// created with the defaults if it does not exist.
+func SaveProfile(path, name string, config *Config, makeDefault bool) error {
+	exists, err := file.Exists(path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		dc := DefaultConfig()
+		if err = WriteToFile(&dc, path); err != nil {
+			return err
+		}
+	}
+	root, doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	profile, err := profileNode(config)
+	if err != nil {
+		return err
+	}
+	profilesNode := mappingValue(root, profilesKey)
+	if profilesNode == nil || profilesNode.Kind != yaml3.MappingNode {
+		profilesNode = &yaml3.Node{Kind: yaml3.MappingNode}
+		setMappingValue(root, profilesKey, profilesNode)
+	}
+	setMappingValue(profilesNode, name, profile)
+	if makeDefault {
+		setMappingValue(root, defaultProfileKey, &yaml3.Node{Kind: yaml3.ScalarNode, Value: name})
+	}
+	b, err := encodeNode(doc)
+	if err != nil {
+		return err
+	}
+	return file.CreateMissingDirsAndFileWithRWPerms(path, b)
+}
+
+func profileNode(config *Config) (*yaml3.Node, error) {
+	t, _ := template.New("profile").Parse(profileTemplate)
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, *config); err != nil {
+		return nil, err
+	}
+	var doc yaml3.Node
+	if err := yaml3.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return nil, err
+	}
+	return doc.Content[0], nil
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+const profilesConfig = `hazelcast:
+  cluster:
+    name: dev
+    network:
+      addresses:
+        - localhost:5701
+defaultprofile: staging
+profiles:
+  staging:
+    hazelcast:
+      cluster:
+        name: staging
+        network:
+          addresses:
+            - 10.0.0.1:5701
+  prod:
+    hazelcast:
+      cluster:
+        name: prod
+    ssl:
+      enabled: true
+`
+
+func TestReadConfig_Profile(t *testing.T) {
+	path := uniquePathWithContent(t.TempDir(), []byte(profilesConfig))
+	testCases := []struct {
+		name          string
+		profile       string
+		expectProfile string
+		expectName    string
+		expectAddrs   []string
+		expectSSL     bool
+		errMsg        string
+	}{
+		{
+			name:          "Profile: blank, Expect: default profile",
+			expectProfile: "staging",
+			expectName:    "staging",
+			expectAddrs:   []string{"10.0.0.1:5701"},
+		},
+		{
+			name:          "Profile: prod, Expect: prod overrides the top level configuration",
+			profile:       "prod",
+			expectProfile: "prod",
+			expectName:    "prod",
+			expectAddrs:   []string{"localhost:5701"},
+			expectSSL:     true,
+		},
+		{
+			name:    "Profile: missing, Expect: error",
+			profile: "test",
+			errMsg:  `profile "test" is not found in ` + path + `, available profiles: prod, staging`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			err := readConfig(path, tc.profile, &cfg, path)
+			if tc.errMsg != "" {
+				require.EqualError(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectProfile, cfg.Profile)
+			require.Equal(t, tc.expectName, cfg.Hazelcast.Cluster.Name)
+			require.Equal(t, tc.expectAddrs, cfg.Hazelcast.Cluster.Network.Addresses)
+			require.Equal(t, tc.expectSSL, cfg.SSL.Enabled)
+		})
+	}
+}
+
+func TestSaveProfile(t *testing.T) {
+	path := uniquePath(t.TempDir())
+	c := DefaultConfig()
+	c.Hazelcast.Cluster.Name = "prod"
+	c.Hazelcast.Cluster.Network.Addresses = []string{"10.0.0.1:5701", "10.0.0.2:5701"}
+	require.NoError(t, SaveProfile(path, "prod", &c, true))
+	c.Hazelcast.Cluster.Name = "staging"
+	require.NoError(t, SaveProfile(path, "staging", &c, false))
+	names, defaultProfile, err := ProfileNames(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"prod", "staging"}, names)
+	require.Equal(t, "prod", defaultProfile)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	// the comments of the default configuration are kept
+	require.Contains(t, string(b), "# disables auto completion on interactive mode if true")
+	cfg := DefaultConfig()
+	require.NoError(t, readConfig(path, "", &cfg, path))
+	require.Equal(t, "prod", cfg.Hazelcast.Cluster.Name)
+	require.Equal(t, []string{"10.0.0.1:5701", "10.0.0.2:5701"}, cfg.Hazelcast.Cluster.Network.Addresses)
+	// the top level configuration is not changed
+	cfg = Config{}
+	require.NoError(t, yaml.Unmarshal(b, &cfg))
+	require.Equal(t, "dev", cfg.Hazelcast.Cluster.Name)
+	exists, err := ProfileExists(path, "staging")
+	require.NoError(t, err)
+	require.True(t, exists)
+	exists, err = ProfileExists(path, "test")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
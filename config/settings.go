@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+const redacted = "<redacted>"
+
+// Setting is a value of the effective configuration with its source.
+type Setting struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+// settings are the configuration values reported by EffectiveSettings, flag returns true if the value is set by a flag.
+var settings = []struct {
+	key    string
+	secret bool
+	value  func(c *Config) interface{}
+	flag   func(f *GlobalFlagValues) bool
+}{
+	{
+		key:   "hazelcast.cluster.name",
+		value: func(c *Config) interface{} { return c.Hazelcast.Cluster.Name },
+		flag:  func(f *GlobalFlagValues) bool { return f.Cluster != "" },
+	},
+	{
+		key:   "hazelcast.cluster.network.addresses",
+		value: func(c *Config) interface{} { return c.Hazelcast.Cluster.Network.Addresses },
+		flag:  func(f *GlobalFlagValues) bool { return f.Address != "" },
+	},
+	{
+		key:   "hazelcast.cluster.network.connectiontimeout",
+		value: func(c *Config) interface{} { return c.Hazelcast.Cluster.Network.ConnectionTimeout },
+	},
+	{
+		key:   "hazelcast.cluster.unisocket",
+		value: func(c *Config) interface{} { return c.Hazelcast.Cluster.Unisocket },
+	},
+	{
+		key:   "hazelcast.cluster.cloud.enabled",
+		value: func(c *Config) interface{} { return c.Hazelcast.Cluster.Cloud.Enabled },
+		flag:  func(f *GlobalFlagValues) bool { return f.Token != "" },
+	},
+	{
+		key:    "hazelcast.cluster.cloud.token",
+		secret: true,
+		value:  func(c *Config) interface{} { return c.Hazelcast.Cluster.Cloud.Token },
+		flag:   func(f *GlobalFlagValues) bool { return f.Token != "" },
+	},
+	{
+		key:   "hazelcast.cluster.security.credentials.username",
+		value: func(c *Config) interface{} { return c.Hazelcast.Cluster.Security.Credentials.Username },
+	},
+	{
+		key:    "hazelcast.cluster.security.credentials.password",
+		secret: true,
+		value:  func(c *Config) interface{} { return c.Hazelcast.Cluster.Security.Credentials.Password },
+	},
+	{
+		key:   "hazelcast.cluster.discovery.usepublicip",
+		value: func(c *Config) interface{} { return c.Hazelcast.Cluster.Discovery.UsePublicIP },
+	},
+	{
+		key:   "hazelcast.logger.level",
+		value: func(c *Config) interface{} { return c.Hazelcast.Logger.Level },
+		flag:  func(f *GlobalFlagValues) bool { return f.LogLevel != "" || f.Verbose },
+	},
+	{
+		key:   "ssl.enabled",
+		value: func(c *Config) interface{} { return c.SSL.Enabled },
+	},
+	{
+		key:   "ssl.servername",
+		value: func(c *Config) interface{} { return c.SSL.ServerName },
+	},
+	{
+		key:   "ssl.insecureskipverify",
+		value: func(c *Config) interface{} { return c.SSL.InsecureSkipVerify },
+	},
+	{
+		key:   "ssl.capath",
+		value: func(c *Config) interface{} { return c.SSL.CAPath },
+	},
+	{
+		key:   "ssl.certpath",
+		value: func(c *Config) interface{} { return c.SSL.CertPath },
+	},
+	{
+		key:   "ssl.keypath",
+		value: func(c *Config) interface{} { return c.SSL.KeyPath },
+	},
+	{
+		key:    "ssl.keypassword",
+		secret: true,
+		value:  func(c *Config) interface{} { return c.SSL.KeyPassword },
+	},
+	{
+		key:   "noautocompletion",
+		value: func(c *Config) interface{} { return c.NoAutocompletion },
+		flag:  func(f *GlobalFlagValues) bool { return f.NoAutocompletion },
+	},
+	{
+		key:   "styling.theme",
+		value: func(c *Config) interface{} { return c.Styling.Theme },
+		flag:  func(f *GlobalFlagValues) bool { return f.NoColor },
+	},
+	{
+		key:   "logger.logfile",
+		value: func(c *Config) interface{} { return c.Logger.LogFile },
+		flag:  func(f *GlobalFlagValues) bool { return f.LogFile != "" },
+	},
+}
+
+// EffectiveSettings reads the configuration file like ReadAndMergeWithFlags does, and returns the resulting values
+// with their sources, the secrets are redacted. The name of the applied profile is returned as well.
+func EffectiveSettings(flags *GlobalFlagValues) ([]Setting, string, error) {
+	c := DefaultConfig()
+	if err := ReadAndMergeWithFlags(flags, &c); err != nil {
+		return nil, "", err
+	}
+	// the log file flag is applied by SetupLogger
+	if flags.LogFile != "" {
+		c.Logger.LogFile = flags.LogFile
+	}
+	root, _, err := readDocument(flags.CfgFile)
+	if err != nil {
+		return nil, "", err
+	}
+	profile := lookupPath(root, []string{profilesKey, c.Profile})
+	result := make([]Setting, len(settings))
+	for i, s := range settings {
+		result[i] = Setting{Key: s.key, Value: formatValue(s.value(&c), s.secret), Source: SourceDefault}
+		path := splitKey(s.key)
+		switch {
+		case s.flag != nil && s.flag(flags):
+			result[i].Source = SourceFlag
+		case envVarOf(s.key) != "":
+			result[i].Source = fmt.Sprintf("%s (%s)", SourceEnv, envVarOf(s.key))
+		case c.Profile != "" && lookupPath(profile, path) != nil:
+			result[i].Source = fmt.Sprintf("%s (profile %s)", SourceFile, c.Profile)
+		case lookupPath(root, path) != nil:
+			result[i].Source = SourceFile
+		}
+	}
+	return result, c.Profile, nil
+}
+
+func formatValue(v interface{}, secret bool) string {
+	var s string
+	switch v := v.(type) {
+	case []string:
+		s = strings.Join(v, ", ")
+	default:
+		s = fmt.Sprint(v)
+	}
+	if secret && s != "" {
+		return redacted
+	}
+	return s
+}
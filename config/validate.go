@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client/logger"
+	"gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// Problem is an issue found in the configuration file.
+type Problem struct {
+	Line    int
+	Key     string
+	Message string
+}
+
+const (
+	errNotAMapping    = "should be a mapping"
+	errNotASequence   = "should be a sequence"
+	errUnknownKey     = "unknown key"
+	errProfileMissing = "profile %q is not found"
+)
+
+var (
+	configType      = reflect.TypeOf(Config{})
+	yamlUnmarshaler = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	sslPathKeys     = []string{"capath", "certpath", "keypath"}
+	logLevelKeyPath = []string{"hazelcast", "logger", "level"}
+	profileKeys     = map[string]bool{profilesKey: true, defaultProfileKey: true}
+)
+
+// Validate checks the configuration file for unknown keys, invalid log levels and SSL files which can not be read.
+// The problems are ordered by their lines.
+func Validate(path string) ([]Problem, error) {
+	root, _, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	return validateDocument(root), nil
+}
+
+func validateDocument(root *yaml3.Node) []Problem {
+	var v validator
+	v.walk(root, configType, "", profileKeys)
+	v.checkValues(root, "")
+	profiles := lookupPath(root, []string{profilesKey})
+	if profiles != nil && profiles.Kind == yaml3.MappingNode {
+		for i := 0; i+1 < len(profiles.Content); i += 2 {
+			key := joinKey(profilesKey, profiles.Content[i].Value)
+			profile := resolveAlias(profiles.Content[i+1])
+			v.walk(profile, configType, key, nil)
+			v.checkValues(profile, key)
+		}
+	} else if profiles != nil && !isNull(profiles) {
+		v.add(profiles, profilesKey, errNotAMapping)
+	}
+	if dp := lookupPath(root, []string{defaultProfileKey}); dp != nil && dp.Value != "" {
+		if lookupPath(root, []string{profilesKey, dp.Value}) == nil {
+			v.add(dp, defaultProfileKey, fmt.Sprintf(errProfileMissing, dp.Value))
+		}
+	}
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Line < v.problems[j].Line
+	})
+	return v.problems
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(n *yaml3.Node, key, msg string) {
+	v.problems = append(v.problems, Problem{Line: n.Line, Key: key, Message: msg})
+}
+
+// walk reports the keys of the node which are not fields of the given type, following the field naming of the YAML
+// decoder which reads the configuration. The keys in skip are not reported.
+func (v *validator) walk(n *yaml3.Node, t reflect.Type, key string, skip map[string]bool) {
+	n = resolveAlias(n)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if n == nil || isNull(n) || isLeaf(t) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.Kind != yaml3.MappingNode {
+			v.add(n, key, errNotAMapping)
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k := n.Content[i]
+			if skip[k.Value] {
+				continue
+			}
+			ft, ok := fields[k.Value]
+			if !ok {
+				v.add(k, joinKey(key, k.Value), errUnknownKey)
+				continue
+			}
+			v.walk(n.Content[i+1], ft, joinKey(key, k.Value), nil)
+		}
+	case reflect.Map:
+		if n.Kind != yaml3.MappingNode {
+			v.add(n, key, errNotAMapping)
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v.walk(n.Content[i+1], t.Elem(), joinKey(key, n.Content[i].Value), nil)
+		}
+	case reflect.Slice, reflect.Array:
+		if n.Kind != yaml3.SequenceNode {
+			v.add(n, key, errNotASequence)
+			return
+		}
+		for i, item := range n.Content {
+			v.walk(item, t.Elem(), fmt.Sprintf("%s[%d]", key, i), nil)
+		}
+	}
+}
+
+// checkValues reports the invalid log level and the SSL files which can not be read in the configuration or profile.
+func (v *validator) checkValues(n *yaml3.Node, key string) {
+	if level := lookupPath(n, logLevelKeyPath); level != nil && level.Kind == yaml3.ScalarNode && level.Value != "" {
+		if _, err := logger.WeightForLogLevel(logger.Level(level.Value)); err != nil {
+			msg := fmt.Sprintf("invalid log level %q, should be one of %s", level.Value, strings.Join(ValidLogLevels, ", "))
+			v.add(level, joinKey(key, strings.Join(logLevelKeyPath, ".")), msg)
+		}
+	}
+	for _, k := range sslPathKeys {
+		p := lookupPath(n, []string{"ssl", k})
+		if p == nil || p.Kind != yaml3.ScalarNode || p.Value == "" {
+			continue
+		}
+		f, err := os.Open(p.Value)
+		if err != nil {
+			v.add(p, joinKey(key, "ssl."+k), fmt.Sprintf("can not read the file: %s", err))
+			continue
+		}
+		f.Close()
+	}
+}
+
+// yamlFields returns the keys of the struct fields, which are the lower case field names unless set by the yaml tag.
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			// unexported
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			for k, ft := range yamlFields(f.Type) {
+				fields[k] = ft
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// isLeaf returns true if the value of the type is decoded as a whole, so its keys are not checked.
+func isLeaf(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+	pt := reflect.PtrTo(t)
+	if t.Implements(yamlUnmarshaler) || pt.Implements(yamlUnmarshaler) || t.Implements(textUnmarshaler) || pt.Implements(textUnmarshaler) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return false
+	}
+	return true
+}
+
+func isNull(n *yaml3.Node) bool {
+	return n.Kind == yaml3.ScalarNode && n.Tag == "!!null"
+}
+
+func joinKey(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, nil, 0600))
+	path := uniquePathWithContent(dir, []byte(`hazelcast:
+  cluster:
+    name: dev
+    network:
+      addresses: localhost:5701
+  logger:
+    level: loud
+ssl:
+  capath: `+caPath+`
+  keypath: /not/exists.pem
+styling:
+  theme: default
+  colorpalette:
+    border: "#ffffff"
+defaultprofile: staging
+profiles:
+  prod:
+    hazelcast:
+      clustr:
+        name: prod
+`))
+	problems, err := Validate(path)
+	require.NoError(t, err)
+	require.Equal(t, []Problem{
+		{Line: 5, Key: "hazelcast.cluster.network.addresses", Message: "should be a sequence"},
+		{Line: 7, Key: "hazelcast.logger.level", Message: `invalid log level "loud", should be one of off, fatal, error, warn, info, debug, trace`},
+		{Line: 10, Key: "ssl.keypath", Message: "can not read the file: open /not/exists.pem: no such file or directory"},
+		{Line: 15, Key: "defaultprofile", Message: `profile "staging" is not found`},
+		{Line: 19, Key: "profiles.prod.hazelcast.clustr", Message: "unknown key"},
+	}, problems)
+	// the default configuration is valid
+	path = uniquePath(dir)
+	c := DefaultConfig()
+	require.NoError(t, WriteToFile(&c, path))
+	problems, err = Validate(path)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
+
+func TestGetAndSetValue(t *testing.T) {
+	path := uniquePath(t.TempDir())
+	c := DefaultConfig()
+	require.NoError(t, WriteToFile(&c, path))
+	require.NoError(t, SetValue(path, "hazelcast.cluster.name", "prod"))
+	require.NoError(t, SetValue(path, "hazelcast.cluster.network.addresses", "[a:5701, b:5701]"))
+	require.NoError(t, SetValue(path, "profiles.staging.hazelcast.logger.level", "debug"))
+	v, err := GetValue(path, "hazelcast.cluster.name")
+	require.NoError(t, err)
+	require.Equal(t, "prod", v)
+	v, err = GetValue(path, "hazelcast.cluster.network.addresses")
+	require.NoError(t, err)
+	require.Equal(t, "['a:5701', 'b:5701']", v)
+	v, err = GetValue(path, "profiles.staging")
+	require.NoError(t, err)
+	require.Equal(t, "hazelcast:\n  logger:\n    level: debug", v)
+	_, err = GetValue(path, "hazelcast.cluster.cloud.url")
+	require.EqualError(t, err, "hazelcast.cluster.cloud.url is not set in "+path)
+	// the invalid values are not written
+	require.EqualError(t, SetValue(path, "hazelcast.clustr.name", "x"), "hazelcast.clustr: unknown key")
+	require.EqualError(t, SetValue(path, "hazelcast.logger.level", "loud"), `hazelcast.logger.level: invalid log level "loud", should be one of off, fatal, error, warn, info, debug, trace`)
+	require.EqualError(t, SetValue(path, "hazelcast.cluster.name.first", "x"), "hazelcast.cluster.name is not a mapping")
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(b), "# 0s is no timeout")
+	require.NotContains(t, string(b), "clustr")
+	cfg := DefaultConfig()
+	require.NoError(t, readConfig(path, "staging", &cfg, path))
+	require.Equal(t, "prod", cfg.Hazelcast.Cluster.Name)
+	require.Equal(t, []string{"a:5701", "b:5701"}, cfg.Hazelcast.Cluster.Network.Addresses)
+	require.Equal(t, "debug", string(cfg.Hazelcast.Logger.Level))
+}
+
+func TestEffectiveSettings(t *testing.T) {
+	path := uniquePathWithContent(t.TempDir(), []byte(`hazelcast:
+  cluster:
+    name: dev
+    cloud:
+      token: secret
+defaultprofile: prod
+profiles:
+  prod:
+    hazelcast:
+      cluster:
+        name: prod
+`))
+	settings, profile, err := EffectiveSettings(&GlobalFlagValues{CfgFile: path, Address: "10.0.0.1:5701"})
+	require.NoError(t, err)
+	require.Equal(t, "prod", profile)
+	values := map[string]Setting{}
+	for _, s := range settings {
+		values[s.Key] = s
+	}
+	require.Equal(t, Setting{Key: "hazelcast.cluster.name", Value: "prod", Source: "file (profile prod)"}, values["hazelcast.cluster.name"])
+	require.Equal(t, Setting{Key: "hazelcast.cluster.network.addresses", Value: "10.0.0.1:5701", Source: SourceFlag}, values["hazelcast.cluster.network.addresses"])
+	require.Equal(t, Setting{Key: "hazelcast.cluster.cloud.token", Value: "<redacted>", Source: SourceFile}, values["hazelcast.cluster.cloud.token"])
+	require.Equal(t, Setting{Key: "hazelcast.cluster.unisocket", Value: "true", Source: SourceDefault}, values["hazelcast.cluster.unisocket"])
+}
@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package config
+
+import (
+	"bytes"
+	"fmt"
+
+	yaml3 "gopkg.in/yaml.v3"
+)
+
+// The configuration file is edited through its YAML nodes, so that the comments and the order of the keys are kept.
+
+// documentMapping returns the top level mapping of the document, which is created if the document is empty.
+func documentMapping(doc *yaml3.Node) (*yaml3.Node, error) {
+	if doc.Kind == 0 {
+		doc.Kind = yaml3.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml3.Node{{Kind: yaml3.MappingNode}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml3.MappingNode {
+		return nil, fmt.Errorf("the top level of the document is not a mapping")
+	}
+	return root, nil
+}
+
+// mappingValue returns the value of the key in the mapping node, nil if the key does not exist.
+func mappingValue(m *yaml3.Node, key string) *yaml3.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of the key in the mapping node, the key is appended if it does not exist.
+func setMappingValue(m *yaml3.Node, key string, value *yaml3.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, &yaml3.Node{Kind: yaml3.ScalarNode, Value: key}, value)
+}
+
+func encodeNode(doc *yaml3.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// lookupPath returns the node of the key path in the mapping node, nil if it does not exist.
+func lookupPath(m *yaml3.Node, path []string) *yaml3.Node {
+	n := m
+	for _, key := range path {
+		n = resolveAlias(n)
+		if n == nil || n.Kind != yaml3.MappingNode {
+			return nil
+		}
+		n = mappingValue(n, key)
+	}
+	return resolveAlias(n)
+}
+
+func resolveAlias(n *yaml3.Node) *yaml3.Node {
+	if n != nil && n.Kind == yaml3.AliasNode {
+		return n.Alias
+	}
+	return n
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package configcmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+)
+
+// New returns the config command group, which works on the configuration file given by the flags.
+func New(flags *config.GlobalFlagValues) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "config {show | get | set | validate | path}",
+		Short: "View, edit and validate the configuration",
+		Long: `View, edit and validate the configuration file.
+The commands run even if the configuration file is not valid, so that it can be fixed.`,
+		// the configuration file may be the reason to run these commands
+		Annotations:        map[string]string{config.OptionalConfigAnnotation: ""},
+		DisableFlagParsing: true,
+		RunE:               hzcerrors.RootRunnerFnc,
+	}
+	cmd.AddCommand(NewShow(flags))
+	cmd.AddCommand(NewGet(flags))
+	cmd.AddCommand(NewSet(flags))
+	cmd.AddCommand(NewValidate(flags))
+	cmd.AddCommand(NewPath(flags))
+	return &cmd
+}
+
+func NewShow(flags *config.GlobalFlagValues) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Show the effective configuration",
+		Long: `Show the configuration which is used to connect, after applying the profile, the environment variables and the flags.
+The source of each value is shown, which is the configuration file, an environment variable, a flag or the default. The secrets are redacted.`,
+		Args:    cobra.NoArgs,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings, profile, err := config.EffectiveSettings(flags)
+			if err != nil {
+				return err
+			}
+			return writeSettings(cmd.OutOrStdout(), flags.CfgFile, profile, settings)
+		},
+	}
+}
+
+func writeSettings(out io.Writer, path, profile string, settings []config.Setting) error {
+	if profile == "" {
+		profile = "none"
+	}
+	if _, err := fmt.Fprintf(out, "Configuration File: %s\nProfile           : %s\n", path, profile); err != nil {
+		return err
+	}
+	tw := table.NewBufferedTableWriter(out)
+	if err := tw.WriteHeader("Key", "Value", "Source"); err != nil {
+		return err
+	}
+	for _, s := range settings {
+		if err := tw.Write(s.Key, s.Value, s.Source); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func NewGet(flags *config.GlobalFlagValues) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get key",
+		Short: "Print a value of the configuration file",
+		Long: `Print the value of the key in the configuration file, the key is the path of the YAML keys separated by dots.
+Mappings and sequences are printed as YAML.`,
+		Example: `  config get hazelcast.cluster.name
+  config get profiles.prod`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v, err := config.GetValue(flags.CfgFile, args[0])
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), v)
+			return err
+		},
+	}
+}
+
+func NewSet(flags *config.GlobalFlagValues) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set key value",
+		Short: "Set a value in the configuration file",
+		Long: `Set the value of the key in the configuration file, the key is the path of the YAML keys separated by dots.
+The value is YAML, e.g. "[a, b]" is a sequence. The comments in the configuration file are kept.
+The file is not changed if the key is not a configuration key or the value is not valid.`,
+		Example: `  config set hazelcast.cluster.name dev
+  config set hazelcast.cluster.network.addresses "[192.168.1.1:5701, 192.168.1.2:5701]"
+  config set profiles.prod.hazelcast.logger.level debug`,
+		Args:    cobra.ExactArgs(2),
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.SetValue(flags.CfgFile, args[0], args[1]); err != nil {
+				return err
+			}
+			cmd.Printf("%s is set, the change is applied on the next start of hzc or connect\n", args[0])
+			return nil
+		},
+	}
+}
+
+func NewValidate(flags *config.GlobalFlagValues) *cobra.Command {
+	return &cobra.Command{
+		Use:     "validate",
+		Short:   "Validate the configuration file",
+		Long:    `Report the unknown keys, invalid log levels and the SSL files which can not be read in the configuration file, with their line numbers.`,
+		Args:    cobra.NoArgs,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			problems, err := config.Validate(flags.CfgFile)
+			if err != nil {
+				return err
+			}
+			if len(problems) == 0 {
+				cmd.Printf("%s is valid\n", flags.CfgFile)
+				return nil
+			}
+			if err := writeProblems(cmd.OutOrStdout(), flags.CfgFile, problems); err != nil {
+				return err
+			}
+			noun := "problems"
+			if len(problems) == 1 {
+				noun = "problem"
+			}
+			return hzcerrors.NewLoggableError(nil, "%d %s found in %s", len(problems), noun, flags.CfgFile)
+		},
+	}
+}
+
+func writeProblems(out io.Writer, path string, problems []config.Problem) error {
+	for _, p := range problems {
+		if _, err := fmt.Fprintf(out, "%s:%d: %s: %s\n", path, p.Line, p.Key, p.Message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewPath(flags *config.GlobalFlagValues) *cobra.Command {
+	return &cobra.Command{
+		Use:     "path",
+		Short:   "Print the path of the configuration file",
+		Args:    cobra.NoArgs,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), flags.CfgFile)
+			return err
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package configcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+)
+
+func TestValidate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	flags := config.GlobalFlagValues{CfgFile: path}
+	tcs := []struct {
+		content string
+		output  string
+		errMsg  string
+	}{
+		{
+			content: "hazelcast:\n  cluster:\n    name: dev\n",
+			output:  path + " is valid\n",
+		},
+		{
+			content: "hazelcast:\n  clustr:\n    name: dev\n  logger:\n    level: loud\n",
+			output: path + ":2: hazelcast.clustr: unknown key\n" +
+				path + `:5: hazelcast.logger.level: invalid log level "loud", should be one of off, fatal, error, warn, info, debug, trace` + "\n",
+			errMsg: "2 problems found in " + path,
+		},
+	}
+	for _, tc := range tcs {
+		require.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+		cmd := New(&flags)
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		cmd.SetArgs([]string{"validate"})
+		err := cmd.Execute()
+		if tc.errMsg != "" {
+			require.EqualError(t, err, tc.errMsg)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, tc.output, out.String())
+	}
+}
@@ -9,11 +9,19 @@ import (
 )
 
 func New() *cobra.Command {
+	var profile string
 	cmd := cobra.Command{
-		Use:   "connection-wizard",
+		Use:   "connection-wizard [--save-profile name]",
 		Short: "Assist with connection configuration",
+		Long: `Assist with connection configuration, which is saved as a named profile in the configuration file and made the default profile.
+The other profiles and the rest of the configuration file are kept.`,
+		Example: `  connection-wizard --save-profile prod
+  hzc --profile prod # connect using the profile`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			exists := config.ConfigExists()
+			exists, err := config.ProfileExists(config.DefaultConfigPath(), profile)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Can not read the profiles during connection-wizard.")
+			}
 			m := InitializeListModel()
 			if err := tea.NewProgram(m).Start(); err != nil {
 				return err
@@ -29,7 +37,7 @@ func New() *cobra.Command {
 			case "Standalone (Remote or Local)":
 				im = StandaloneInput(&c)
 			case "Local (Default)":
-				return handleWrite(cmd, &c, "y")
+				return handleWrite(cmd, &c, profile, "y")
 			}
 			if err := tea.NewProgram(im).Start(); err != nil {
 				return hzcerrors.NewLoggableError(err, "Can not run list model during connection-wizard.")
@@ -44,9 +52,12 @@ func New() *cobra.Command {
 					return hzcerrors.NewLoggableError(err, "Can not run input model during connection-wizard.")
 				}
 			}
-			return handleWrite(cmd, &c, choice)
+			return handleWrite(cmd, &c, profile, choice)
 		},
 	}
+	// the profile flag of the root command selects the profile to connect with, which may not exist yet,
+	// so the profile to save has its own flag
+	cmd.Flags().StringVar(&profile, "save-profile", config.DefaultProfileName, "name of the profile to save the connection configuration to")
 	return &cmd
 }
 
@@ -60,14 +71,14 @@ func handleExit(cmd *cobra.Command) error {
 	return nil
 }
 
-func handleWrite(cmd *cobra.Command, c *config.Config, choice string) error {
+func handleWrite(cmd *cobra.Command, c *config.Config, profile, choice string) error {
 	if choice == "y" {
 		exists := config.ConfigExists()
-		err := config.WriteToFile(c, config.DefaultConfigPath())
+		err := config.SaveProfile(config.DefaultConfigPath(), profile, c, true)
 		if err != nil {
-			return hzcerrors.NewLoggableError(err, "There was an error during overwriting config file.")
+			return hzcerrors.NewLoggableError(err, "There was an error during saving the profile %q to the config file.", profile)
 		} else if exists {
-			cmd.Println("Your config file has been changed. Please re-start CLC to apply new config.")
+			cmd.Printf("The profile %q is saved and made the default profile. Please re-start CLC to apply new config.\n", profile)
 		}
 	}
 	return nil
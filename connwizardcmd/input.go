@@ -31,7 +31,7 @@ const (
 	keyPathMsg        = "• SSL Key Path: "
 	passwordMsg       = "• SSL Password: "
 
-	approvalMsg = "Your config file will be overwritten, do you want to continue? (y/n): "
+	approvalMsg = "The profile already exists and will be overwritten, do you want to continue? (y/n): "
 	submitMsg   = "[ Submit ]"
 
 	viridianInfoMsg   = "Please provide your Hazelcast Viridian tokens below."
@@ -45,6 +45,10 @@ To enable REST API, follow the instructions in the member log or see the documen
 For operations that change state/configuration of the cluster (e.g. "cluster change-state"), you need to have "CLUSTER_WRITE" permission on the REST API to prevent unauthorized changes.
 
 To change CLUSTER_WRITE permission, see the documentation: https://docs.hazelcast.com/hazelcast/latest/maintain-cluster/rest-api#using-rest-endpoint-groups`
+	restOrPersistenceEnabledMsg = restEnabledMsg + "\n\n" + `- If yes, is PERSISTENCE endpoint group enabled?
+For persistence operations (e.g. "cluster persistence force-start"), you need to have "PERSISTENCE" permission on the REST API, which is called "HOT_RESTART" before Hazelcast 5.0.
+
+To change PERSISTENCE permission, see the documentation: https://docs.hazelcast.com/hazelcast/latest/maintain-cluster/rest-api#using-rest-endpoint-groups`
 )
 
 func ErrorRecover(out io.Writer) {
@@ -66,8 +70,11 @@ func TranslateError(err error, isCloudCluster bool, op ...string) (string, bool)
 func TranslateClusterError(err error, operation string) (string, bool) {
 	var urlErr *url.Error
 	if errors.As(err, &urlErr) && strings.Contains(urlErr.Error(), "EOF") {
-		if operation == internal.ClusterShutdown || operation == internal.ClusterChangeState {
+		switch operation {
+		case internal.ClusterShutdown, internal.MemberShutdown, internal.ClusterChangeState, internal.LogLevelSet, internal.LogLevelReset:
 			return restOrClusterWriteEnabledMsg, true
+		case internal.PersistenceForceStart, internal.PersistencePartialStart, internal.PersistenceBackup, internal.PersistenceBackupInterrupt:
+			return restOrPersistenceEnabledMsg, true
 		}
 		return restEnabledMsg, true
 	}
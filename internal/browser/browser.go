@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -17,24 +18,51 @@ import (
 
 	"github.com/hazelcast/hazelcast-commandline-client/internal/browser/layout/vertical"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/browser/multiline"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/explain"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/viewer"
 	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
 )
 
+// sqlHistoryFile is the name of the SQL browser history file in the CLC home directory.
+const sqlHistoryFile = "sql-history"
+
 type StringResultMsg string
 type TableResultMsg sql.Result
 
 type controller struct {
 	tea.Model
-	client *hazelcast.Client
+	client    *hazelcast.Client
+	completer *sqlCompleter
+	tabs      *tabSet
+	library   *library
+	prompt    linePrompt
+	// size is the last window size, to lay out the components of the new tabs
+	size *tea.WindowSizeMsg
 }
 
 type table struct {
 	termdbmsTable viewer.TuiModel
 	keyboardFocus bool
 	lastIteration *SQLIterator
+	grid          grid
+	// received is the number of the rows of the last result passed to the grid, the rows are kept by lastIteration
+	received int
+	// shown are the rows passed to the viewer, with all the columns
+	shown  [][]interface{}
+	detail *rowDetail
+	// exporting is set while the result is exported, so that one export of the table runs at a time
+	exporting int32
+	// exportedRows is the number of the rows fetched by the running export, shown in the separator
+	exportedRows int64
+	// autoFetches counts the fetches to fill the view since the result or the filters changed
+	autoFetches int
 }
 
+// maxAutoFetches is the number of times the rows are fetched to fill the view which is left with too few rows by the
+// filters, so that a filter which matches no rows does not fetch a streaming result endlessly.
+const maxAutoFetches = 20
+
 func (t *table) Init() tea.Cmd {
 	tuiutil.Faint = true
 	if lipgloss.ColorProfile() == termenv.Ascii || tuiutil.SelectedTheme == tuiutil.NoColor {
@@ -43,8 +71,18 @@ func (t *table) Init() tea.Cmd {
 	}
 	viewer.GlobalCommands["j"] = viewer.GlobalCommands["s"]
 	viewer.GlobalCommands["k"] = viewer.GlobalCommands["w"]
-	viewer.GlobalCommands["a"] = viewer.GlobalCommands["left"]
-	viewer.GlobalCommands["d"] = viewer.GlobalCommands["right"]
+	for _, k := range []string{"a", "h", "left"} {
+		viewer.GlobalCommands[k] = func(m *viewer.TuiModel) tea.Cmd {
+			viewer.SelectPreviousColumn(m)
+			return nil
+		}
+	}
+	for _, k := range []string{"d", "l", "right"} {
+		viewer.GlobalCommands[k] = func(m *viewer.TuiModel) tea.Cmd {
+			viewer.SelectNextColumn(m)
+			return nil
+		}
+	}
 	viewer.GlobalCommands["down"] = viewer.GlobalCommands["s"]
 	viewer.GlobalCommands["up"] = viewer.GlobalCommands["w"]
 	t.termdbmsTable = viewer.GetNewModel("", nil)
@@ -53,12 +91,16 @@ func (t *table) Init() tea.Cmd {
 	return t.termdbmsTable.Init()
 }
 
-type FetchMoreRowsMsg struct{}
+// FetchMoreRowsMsg requests the next rows of the result from source.
+type FetchMoreRowsMsg struct {
+	source *SQLIterator
+}
 
 func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m := msg.(type) {
 	case StringResultMsg:
 		// update table
+		t.detail = nil
 		t.termdbmsTable.UI.RenderSelection = true
 		t.termdbmsTable.Data().EditTextBuffer = string(m)
 		return t, nil
@@ -76,26 +118,48 @@ func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			Data:     make(map[string]interface{}),
 		}
 		t.termdbmsTable.MouseData = tea.MouseEvent{}
+		t.termdbmsTable.Scroll.ScrollXOffset = 0
+		t.termdbmsTable.UI.PinnedColumns = 0
+		t.grid = grid{}
+		t.received = 0
+		t.shown = nil
+		t.detail = nil
+		t.autoFetches = 0
 		var err error
 		if t.lastIteration, err = NewSqlIterator(50, m); err != nil {
 			return t, nil
 		}
 		return t, t.lastIteration.ConsumeRowsCmd(50 * time.Millisecond)
 	case NewRowsMessage:
-		t.PopulateDataForResult(m)
+		all := t.lastIteration.consumedRows()
+		rows := all[t.received:]
+		t.received = len(all)
+		if t.grid.sorted() {
+			// the new rows may go anywhere in the sorted rows
+			t.PopulateDataForResult(t.grid.rows(t.lastIteration.columnNames, all), true)
+		} else {
+			t.PopulateDataForResult(t.grid.rows(t.lastIteration.columnNames, rows), false)
+		}
 		t.termdbmsTable.UI.CurrentTable = 1
 		_ = t.termdbmsTable.NumHeaders() // to set maxHeaders global var, for side effect
 		t.termdbmsTable.SetViewSlices()
 		var cmd tea.Cmd
-		if !t.lastIteration.rowsFinished {
+		if !t.lastIteration.finished() {
 			cmd = t.lastIteration.ConsumeRowsCmd(50 * time.Millisecond)
+			if len(t.termdbmsTable.GetColumnData()) < t.termdbmsTable.Viewport.Height && t.autoFetches < maxAutoFetches {
+				// the filters may leave too few rows to scroll, which would fetch more rows
+				t.autoFetches++
+				si := t.lastIteration
+				cmd = tea.Batch(cmd, func() tea.Msg {
+					return FetchMoreRowsMsg{source: si}
+				})
+			}
 		}
 		return t, cmd
 	case FetchMoreRowsMsg:
-		if t.lastIteration.rowsFinished || atomic.LoadInt32(&t.lastIteration.iterating) == set {
+		if t.lastIteration.finished() || !t.lastIteration.startIterating(50) {
 			return t, nil
 		}
-		go t.lastIteration.Iterate(50)
 		return t, t.lastIteration.ConsumeRowsCmd(50 * time.Millisecond)
 	case tea.KeyMsg:
 		switch m.Type {
@@ -104,19 +168,25 @@ func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			tuiutil.Faint = !tuiutil.Faint
 			return t, nil
 		case tea.KeyCtrlC:
-			if atomic.CompareAndSwapInt32(&t.lastIteration.queryStatus, unset, closed) {
-				// go client halts at the Close call if there is no member to connect
-				// this is a hacky work around
-				go func() {
-					t.lastIteration.result.Close()
-				}()
-				changeProgress(HideProgress)
-			}
+			t.cancelQuery()
 			return t, nil
 		}
 		if !t.keyboardFocus {
 			return t, nil
 		}
+		if t.detail != nil {
+			return t, t.updateDetail(m.String())
+		}
+		switch m.String() {
+		case "enter":
+			t.openDetail()
+			return t, nil
+		case "y", "r", "v":
+			return t, t.copy(m.String())
+		}
+		if t.updateGrid(m.String()) {
+			return t, nil
+		}
 	case tea.MouseMsg:
 		// disable all mouse events
 		return t, nil
@@ -128,17 +198,35 @@ func (t *table) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	t.termdbmsTable, cmd = t.termdbmsTable.Update(msg)
 	newYOffset := t.termdbmsTable.Viewport.YOffset + t.termdbmsTable.GetRow()
 	if t.lastIteration != nil {
-		userOnLastPage := newYOffset > t.lastIteration.totalProcessedLines-t.termdbmsTable.Viewport.Height
+		userOnLastPage := newYOffset > len(t.termdbmsTable.GetColumnData())-t.termdbmsTable.Viewport.Height
 		if newYOffset > oldYOffset && userOnLastPage {
 			cmd = tea.Batch(cmd, func() tea.Msg {
-				return FetchMoreRowsMsg{}
+				return FetchMoreRowsMsg{source: t.lastIteration}
 			})
 		}
 	}
 	return t, cmd
 }
 
-type NewRowsMessage [][]interface{}
+// cancelQuery closes the result of the last query, if it is still running.
+func (t *table) cancelQuery() {
+	if t.lastIteration == nil {
+		return
+	}
+	if atomic.CompareAndSwapInt32(&t.lastIteration.queryStatus, unset, closed) {
+		// go client halts at the Close call if there is no member to connect
+		// this is a hacky work around
+		go func(si *SQLIterator) {
+			si.result.Close()
+		}(t.lastIteration)
+		changeProgress(HideProgress)
+	}
+}
+
+// NewRowsMessage reports that rows are consumed from source, the table takes the rows which it has not received yet.
+type NewRowsMessage struct {
+	source *SQLIterator
+}
 
 const (
 	unset  = 0
@@ -147,15 +235,22 @@ const (
 )
 
 type SQLIterator struct {
-	it                  sql.RowsIterator
-	result              sql.Result
-	resultPipe          chan []interface{}
-	rowsFinished        bool
-	totalProcessedLines int
-	columnNames         []string // cannot access these after it.Close(), hence save them
-	iterating           int32
-	consumingRows       int32
-	queryStatus         int32
+	it         sql.RowsIterator
+	result     sql.Result
+	resultPipe chan []interface{}
+	// rowsFinished is set when the pipe is closed and all its rows are consumed
+	rowsFinished int32
+	columnNames  []string // cannot access these after it.Close(), hence save them
+	iterating    int32
+	// consuming is held while the rows are consumed from the pipe, so that the rows are kept in the order of the result
+	consuming chan struct{}
+	// iterated is signalled when fetching a batch of rows ends before the result is exhausted
+	iterated    chan struct{}
+	queryStatus int32
+	pipeClosed  int32
+	// rows are all the rows consumed from the pipe so far, the table and the export use them
+	rowsMu sync.Mutex
+	rows   [][]interface{}
 }
 
 func NewSqlIterator(maxIterationCount int, result sql.Result) (*SQLIterator, error) {
@@ -173,13 +268,24 @@ func NewSqlIterator(maxIterationCount int, result sql.Result) (*SQLIterator, err
 		si.columnNames = append(si.columnNames, c.Name())
 	}
 	si.resultPipe = make(chan []interface{}, maxIterationCount+1)
-	go si.Iterate(maxIterationCount)
+	si.consuming = make(chan struct{}, 1)
+	si.iterated = make(chan struct{}, 1)
+	si.startIterating(maxIterationCount)
 	return &si, nil
 }
 
+// startIterating fetches the next rows in the background, unless the rows are already being fetched or the
+// result is exhausted. It reports whether the fetching is started.
+func (si *SQLIterator) startIterating(maxIterationCount int) bool {
+	if atomic.LoadInt32(&si.pipeClosed) == set || !atomic.CompareAndSwapInt32(&si.iterating, unset, set) {
+		return false
+	}
+	go si.Iterate(maxIterationCount)
+	return true
+}
+
 func (si *SQLIterator) Iterate(maxIterationCount int) {
 	atomic.StoreInt32(&si.iterating, set)
-	defer atomic.StoreInt32(&si.iterating, unset)
 	var i int
 	for i = 0; si.it.HasNext() && i < maxIterationCount; i++ {
 		rows, err := si.it.Next()
@@ -201,17 +307,34 @@ func (si *SQLIterator) Iterate(maxIterationCount int) {
 	if i < maxIterationCount {
 		changeProgress(HideProgress)
 		// means query finished and there will be no more results
+		atomic.StoreInt32(&si.pipeClosed, set)
 		close(si.resultPipe)
+		atomic.StoreInt32(&si.iterating, unset)
+		return
+	}
+	// unset before signalling, so that the next batch can be started on the signal
+	atomic.StoreInt32(&si.iterating, unset)
+	select {
+	case si.iterated <- struct{}{}:
+	default:
+		// a signal is already pending
 	}
 }
 
+// finished reports whether all the rows of the result are consumed.
+func (si *SQLIterator) finished() bool {
+	return atomic.LoadInt32(&si.rowsFinished) == set
+}
+
 func (si *SQLIterator) ConsumeRowsCmd(deadline time.Duration) func() tea.Msg {
 	return func() tea.Msg {
-		if !atomic.CompareAndSwapInt32(&si.consumingRows, 0, 1) {
-			// already iterating
+		select {
+		case si.consuming <- struct{}{}:
+		default:
+			// already consuming
 			return nil
 		}
-		defer atomic.CompareAndSwapInt32(&si.consumingRows, 1, 0)
+		defer func() { <-si.consuming }()
 		timer := time.NewTimer(deadline)
 		defer timer.Stop()
 		var newRows [][]interface{}
@@ -220,28 +343,61 @@ func (si *SQLIterator) ConsumeRowsCmd(deadline time.Duration) func() tea.Msg {
 			select {
 			case row, ok := <-si.resultPipe:
 				if !ok {
-					si.rowsFinished = true
-					return NewRowsMessage(newRows)
+					atomic.StoreInt32(&si.rowsFinished, set)
+					si.addConsumedRows(newRows)
+					return NewRowsMessage{source: si}
 				}
-				si.totalProcessedLines++
 				newRows = append(newRows, row)
 			case <-timer.C:
 				break loop
 			}
 		}
-		return NewRowsMessage(newRows)
+		si.addConsumedRows(newRows)
+		return NewRowsMessage{source: si}
 	}
 }
 
-func (m *table) PopulateDataForResult(rows [][]interface{}) {
-	columnNames := m.lastIteration.columnNames
+func (si *SQLIterator) addConsumedRows(rows [][]interface{}) {
+	si.rowsMu.Lock()
+	defer si.rowsMu.Unlock()
+	si.rows = append(si.rows, rows...)
+}
+
+// receivedRows returns the rows of the last result which are passed to the grid.
+func (t *table) receivedRows() [][]interface{} {
+	return t.lastIteration.consumedRows()[:t.received]
+}
+
+func (si *SQLIterator) consumedRows() [][]interface{} {
+	si.rowsMu.Lock()
+	defer si.rowsMu.Unlock()
+	return si.rows[:len(si.rows):len(si.rows)]
+}
+
+// PopulateDataForResult passes the rows to the viewer, appending them to the shown rows unless reset is set.
+// Only the columns which are not hidden are passed.
+func (m *table) PopulateDataForResult(rows [][]interface{}, reset bool) {
+	columnNames := m.grid.visibleColumns(m.lastIteration.columnNames)
 	columnValues := make(map[string][]interface{})
-	if m.termdbmsTable.QueryResult != nil && m.termdbmsTable.QueryData != nil && m.termdbmsTable.QueryResult.Data["0"] != nil {
+	if !reset && m.termdbmsTable.QueryResult != nil && m.termdbmsTable.QueryData != nil && m.termdbmsTable.QueryResult.Data["0"] != nil {
 		columnValues = m.termdbmsTable.QueryResult.Data["0"].(map[string][]interface{})
 	}
+	index := make(map[string]int, len(m.lastIteration.columnNames))
+	for i, colName := range m.lastIteration.columnNames {
+		index[colName] = i
+	}
+	if reset {
+		m.shown = nil
+	}
+	if m.grid.filtered() || m.grid.sorted() {
+		m.shown = append(m.shown, rows...)
+	} else {
+		// the rows are shown in the order of the result, so refer to them instead of copying
+		m.shown = m.receivedRows()
+	}
 	for _, row := range rows {
-		for i, colName := range columnNames {
-			columnValues[colName] = append(columnValues[colName], row[i])
+		for _, colName := range columnNames {
+			columnValues[colName] = append(columnValues[colName], row[index[colName]])
 		}
 	}
 	// onto the next schema
@@ -258,6 +414,9 @@ func (m *table) PopulateDataForResult(rows [][]interface{}) {
 }
 
 func (t *table) View() string {
+	if t.detail != nil {
+		return t.detail.view(t.termdbmsTable.Viewport.Width, t.detailHeight())
+	}
 	var wg sync.WaitGroup
 	wg.Add(2)
 	var header, content string
@@ -314,9 +473,78 @@ func (h Help) View() string {
 }
 
 func (c controller) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if c.prompt.active {
+		if k, ok := msg.(tea.KeyMsg); ok {
+			return c.updatePrompt(k)
+		}
+		var cmd tea.Cmd
+		c.prompt.input, cmd = c.prompt.input.Update(msg)
+		var layoutCmd tea.Cmd
+		c.Model, layoutCmd = c.Model.Update(msg)
+		return c, tea.Batch(cmd, layoutCmd)
+	}
 	switch m := msg.(type) {
-	case multiline.SubmitMsg:
+	case tea.WindowSizeMsg:
+		c.size = &m
+	case tea.KeyMsg:
+		c.prompt.status = ""
+		switch {
+		case m.Type == tea.KeyCtrlT:
+			return c.addTab("", "")
+		case m.Type == tea.KeyCtrlN:
+			c.tabs.move(1)
+			return c, nil
+		case m.Type == tea.KeyCtrlP:
+			c.tabs.move(-1)
+			return c, nil
+		case m.Alt && m.String() == "alt+w":
+			cmd := c.tabs.close()
+			return c, tea.Batch(cmd, c.resize())
+		case m.Type == tea.KeyCtrlS:
+			t := c.tabs.current()
+			var name string
+			if _, ok := c.library.get(t.name); ok {
+				name = t.name
+			}
+			return c, c.prompt.start(promptSave, "Save query as", "query name", name, "")
+		case m.Type == tea.KeyCtrlL:
+			hint := "no saved queries"
+			if names := c.library.names(); len(names) > 0 {
+				hint = "saved: " + strings.Join(names, ", ")
+			}
+			return c, c.prompt.start(promptLoad, "Load query", "query name", "", hint)
+		case m.String() == "/" && c.tabs.current().table.keyboardFocus:
+			t := c.tabs.current().table
+			if t.lastIteration == nil || t.termdbmsTable.UI.RenderSelection || t.detail != nil {
+				return c, nil
+			}
+			column := t.termdbmsTable.GetVisibleColumnName()
+			cmd := c.prompt.start(promptFilter, "Filter "+column, "text or /regexp/", t.grid.filterOf(column), "empty value removes the filter")
+			c.prompt.column = column
+			return c, cmd
+		case m.Type == tea.KeyCtrlO:
+			if c.tabs.current().table.lastIteration != nil {
+				return c, c.prompt.start(promptExport, "Export to", "result.csv, result.json or result.md", "", "")
+			}
+			return c, nil
+		}
+	case copiedMsg:
+		c.prompt.status = m.summary()
+		return c, nil
+	case exportDoneMsg:
+		c.prompt.status = m.summary()
+		if m.fetched == 0 {
+			return c, nil
+		}
 		return c, func() tea.Msg {
+			return NewRowsMessage{source: m.source}
+		}
+	case multiline.SubmitMsg:
+		if strings.Contains(strings.ToUpper(string(m)), "MAPPING") {
+			// the mappings or their columns may change
+			c.completer.Invalidate()
+		}
+		return c, c.tabCmd(func() tea.Msg {
 			q := strings.TrimSpace(string(m))
 			if q == "" {
 				return nil
@@ -334,34 +562,110 @@ func (c controller) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return StringResultMsg(err.Error())
 			}
 			return StringResultMsg(fmt.Sprintf("Affected Rows: %d", result.UpdateCount()))
-		}
+		})
+	case multiline.ExplainMsg:
+		return c, c.tabCmd(func() tea.Msg {
+			q := strings.TrimSpace(string(m))
+			plan, err := explain.Plan(context.TODO(), c.client, q)
+			if err != nil {
+				return StringResultMsg(err.Error())
+			}
+			return StringResultMsg(explain.Render(plan, tuiutil.SelectedTheme != tuiutil.NoColor))
+		})
 	}
 	var cmd tea.Cmd
 	c.Model, cmd = c.Model.Update(msg)
 	return c, cmd
 }
 
+// tabCmd delivers the result of cmd to the active tab, even if another tab is activated in the meantime.
+func (c controller) tabCmd(cmd tea.Cmd) tea.Cmd {
+	t := c.tabs.current()
+	return func() tea.Msg {
+		msg := cmd()
+		if msg == nil {
+			return nil
+		}
+		return tabMsg{tab: t, msg: msg}
+	}
+}
+
+func (c controller) addTab(name, query string) (tea.Model, tea.Cmd) {
+	cmd := c.tabs.add(name, query)
+	return c, tea.Batch(cmd, c.resize())
+}
+
+// resize lays out the components again, so that the components of a new tab get their size.
+func (c *controller) resize() tea.Cmd {
+	if c.size == nil {
+		return nil
+	}
+	var cmd tea.Cmd
+	c.Model, cmd = c.Model.Update(*c.size)
+	return cmd
+}
+
 func InitSQLBrowser(client *hazelcast.Client, in io.Reader, out io.Writer) *tea.Program {
-	var s SeparatorWithProgress
-	textArea := multiline.InitTextArea()
-	table := &table{}
-	c := &controller{vertical.InitialModel([]tea.Model{
-		table,
+	// the history is kept in memory only if it cannot be loaded
+	history, _ := multiline.LoadHistory(filepath.Join(file.HZCHomePath(), sqlHistoryFile))
+	// the queries cannot be saved if the saved queries cannot be loaded, the error is shown until the first key press
+	lib, libErr := loadLibrary(filepath.Join(file.HZCHomePath(), savedQueriesFile))
+	completer := newSQLCompleter(client)
+	tabs := &tabSet{
+		newEditor: func(text string) editor {
+			e := multiline.InitTextArea(history, completer.Complete)
+			e.SetValue(text)
+			return e
+		},
+	}
+	tabs.add("", "")
+	s := SeparatorWithProgress{tabs: tabs}
+	c := &controller{Model: vertical.InitialModel([]tea.Model{
+		tabBar{set: tabs},
+		&tabbedTable{set: tabs},
 		&s,
-		textArea,
+		&tabbedEditor{set: tabs},
 		Help{
 			values: []Shortcut{
 				{
 					"^E",
 					"execute",
 				},
+				{
+					"^X",
+					"explain",
+				},
+				{
+					"^R",
+					"search history",
+				},
+				{
+					"^O",
+					"export result",
+				},
+				{
+					"^T/^N/^P/M-w",
+					"new/next/prev/close tab",
+				},
+				{
+					"^S/^L",
+					"save/load query",
+				},
+				{
+					"o / x X p P",
+					"sort/filter/hide/show/pin/unpin column",
+				},
+				{
+					"Enter / y r v",
+					"row detail / copy value/row/result",
+				},
 				{
 					"^Q",
 					"quit",
 				},
 				{
 					"Tab",
-					"toggle focus",
+					"complete / toggle focus",
 				},
 				{
 					"^V",
@@ -378,7 +682,10 @@ func InitSQLBrowser(client *hazelcast.Client, in io.Reader, out io.Writer) *tea.
 			},
 			align: lipgloss.Left,
 		},
-	}, []int{3, -1, 1, -1}), client}
+	}, []int{-1, 3, -1, 1, -1}), client: client, completer: completer, tabs: tabs, library: lib}
+	if libErr != nil {
+		c.prompt.status = fmt.Sprintf("Cannot load the saved queries: %s", libErr)
+	}
 	p := tea.NewProgram(
 		c,
 		tea.WithOutput(out),
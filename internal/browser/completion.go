@@ -0,0 +1,213 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+)
+
+const (
+	// completionQueryTimeout bounds the metadata queries run for completion.
+	completionQueryTimeout = 5 * time.Second
+	// completionRetryInterval is the time to wait after a metadata query fails, e.g. when the session is disconnected.
+	completionRetryInterval = 10 * time.Second
+)
+
+var sqlKeywords = []string{
+	"SELECT", "FROM", "WHERE", "AND", "OR", "NOT", "IN", "IS", "NULL", "LIKE", "BETWEEN", "AS", "DISTINCT",
+	"ORDER", "BY", "GROUP", "HAVING", "LIMIT", "OFFSET", "ASC", "DESC", "JOIN", "INNER", "LEFT", "RIGHT",
+	"OUTER", "CROSS", "ON", "USING", "UNION", "ALL", "CASE", "WHEN", "THEN", "ELSE", "END", "CAST", "COUNT",
+	"SUM", "AVG", "MIN", "MAX", "INSERT", "INTO", "VALUES", "SINK", "UPDATE", "SET", "DELETE", "CREATE",
+	"DROP", "ALTER", "MAPPING", "MAPPINGS", "VIEW", "VIEWS", "JOB", "JOBS", "SNAPSHOT", "INDEX", "TYPE",
+	"OPTIONS", "EXTERNAL", "NAME", "IF", "EXISTS", "REPLACE", "SHOW", "EXPLAIN", "TABLE", "TRUE", "FALSE",
+	"VARCHAR", "BOOLEAN", "TINYINT", "SMALLINT", "INTEGER", "BIGINT", "DECIMAL", "REAL", "DOUBLE", "DATE",
+	"TIME", "TIMESTAMP", "OBJECT", "JSON",
+}
+
+// keywords after which a mapping name is expected
+var mappingKeywords = map[string]struct{}{
+	"FROM":    {},
+	"JOIN":    {},
+	"INTO":    {},
+	"UPDATE":  {},
+	"MAPPING": {},
+	"TABLE":   {},
+}
+
+var (
+	lastWordRe       = regexp.MustCompile(`([A-Za-z_]+)\s*$`)
+	referencedNameRe = regexp.MustCompile(`(?i)\b(?:FROM|JOIN|INTO|UPDATE)\s+"?([A-Za-z0-9_]+)"?`)
+)
+
+// sqlCompleter suggests SQL keywords, mapping names and the columns of the mappings referenced in the statement.
+// Mappings and columns are queried in the background when first needed and cached for the session,
+// so that completion never blocks the editor. The columns are queried only for the loaded mappings.
+type sqlCompleter struct {
+	client   *hazelcast.Client
+	mu       sync.Mutex
+	mappings []string
+	// mappingsState is one of notLoaded, loading or loaded
+	mappingsState int
+	columns       map[string][]string
+	loading       map[string]bool
+	// retryAt is when the loads start again after a load failed
+	retryAt time.Time
+	// generation is incremented by Invalidate, the results of the loads started before are dropped
+	generation int
+}
+
+const (
+	notLoaded = iota
+	loading
+	loaded
+)
+
+func newSQLCompleter(client *hazelcast.Client) *sqlCompleter {
+	return &sqlCompleter{
+		client:  client,
+		columns: make(map[string][]string),
+		loading: make(map[string]bool),
+	}
+}
+
+// Complete returns the candidates for the word typed after before, the statement text up to the word.
+func (c *sqlCompleter) Complete(statement, before string) []string {
+	mappings := c.Mappings()
+	if m := lastWordRe.FindStringSubmatch(before); m != nil {
+		if _, ok := mappingKeywords[strings.ToUpper(m[1])]; ok {
+			return mappings
+		}
+	}
+	candidates := append([]string(nil), sqlKeywords...)
+	for _, m := range referencedNameRe.FindAllStringSubmatch(statement, -1) {
+		// the name may be partly typed
+		if contains(mappings, m[1]) {
+			candidates = append(candidates, c.Columns(m[1])...)
+		}
+	}
+	return append(candidates, mappings...)
+}
+
+// Mappings returns the cached mapping names, starting to load them if they are not loaded yet.
+func (c *sqlCompleter) Mappings() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mappingsState == notLoaded && c.canLoad() {
+		c.mappingsState = loading
+		go c.loadMappings(c.generation)
+	}
+	return c.mappings
+}
+
+// Columns returns the cached column names of the mapping, starting to load them if they are not loaded yet.
+func (c *sqlCompleter) Columns(mapping string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cols, ok := c.columns[mapping]
+	if !ok && !c.loading[mapping] && c.canLoad() {
+		c.loading[mapping] = true
+		go c.loadColumns(c.generation, mapping)
+	}
+	return cols
+}
+
+// Invalidate drops the cache, e.g. after a mapping is created or dropped.
+func (c *sqlCompleter) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.mappingsState = notLoaded
+	c.mappings = nil
+	c.columns = make(map[string][]string)
+	c.loading = make(map[string]bool)
+	c.retryAt = time.Time{}
+}
+
+// canLoad reports whether a load can start, it is false for a while after a load failed.
+// The caller must hold c.mu.
+func (c *sqlCompleter) canLoad() bool {
+	return !time.Now().Before(c.retryAt)
+}
+
+func (c *sqlCompleter) loadMappings(generation int) {
+	names, err := c.queryNames("SELECT table_name FROM information_schema.mappings")
+	c.setMappings(generation, names, err)
+}
+
+// setMappings caches the loaded mapping names, unless the cache was invalidated while loading them.
+func (c *sqlCompleter) setMappings(generation int, names []string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if err != nil {
+		// try again on a completion after a while
+		c.mappingsState = notLoaded
+		c.retryAt = time.Now().Add(completionRetryInterval)
+		return
+	}
+	c.mappings = names
+	c.mappingsState = loaded
+}
+
+func (c *sqlCompleter) loadColumns(generation int, mapping string) {
+	names, err := c.queryNames("SELECT column_name FROM information_schema.columns WHERE table_name = ? ORDER BY ordinal_position", mapping)
+	c.setColumns(generation, mapping, names, err)
+}
+
+// setColumns caches the loaded column names of the mapping, unless the cache was invalidated while loading them.
+func (c *sqlCompleter) setColumns(generation int, mapping string, names []string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	delete(c.loading, mapping)
+	if err != nil {
+		c.retryAt = time.Now().Add(completionRetryInterval)
+		return
+	}
+	c.columns[mapping] = names
+}
+
+func (c *sqlCompleter) queryNames(q string, params ...interface{}) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), completionQueryTimeout)
+	defer cancel()
+	result, err := c.client.SQL().Execute(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+	it, err := result.Iterator()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for it.HasNext() {
+		row, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		v, err := row.Get(0)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, fmt.Sprint(v))
+	}
+	return names, nil
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package browser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLCompleter_InvalidateDropsStaleLoads(t *testing.T) {
+	c := newSQLCompleter(nil)
+	// loads started before Invalidate
+	c.mappingsState = loading
+	c.loading["m"] = true
+	stale := c.generation
+	c.Invalidate()
+	require.Empty(t, c.loading)
+	c.setMappings(stale, []string{"dropped"}, nil)
+	c.setColumns(stale, "m", []string{"old"}, nil)
+	require.Nil(t, c.mappings)
+	require.Equal(t, notLoaded, c.mappingsState)
+	require.NotContains(t, c.columns, "m")
+	// loads started after Invalidate
+	c.setMappings(c.generation, []string{"created"}, nil)
+	c.setColumns(c.generation, "created", []string{"__key", "this"}, nil)
+	require.Equal(t, []string{"created"}, c.mappings)
+	require.Equal(t, []string{"__key", "this"}, c.columns["created"])
+}
+
+func TestSQLCompleter_ColumnsOfLoadedMappings(t *testing.T) {
+	c := newSQLCompleter(nil)
+	c.mappings = []string{"employees"}
+	c.mappingsState = loaded
+	c.columns["employees"] = []string{"__key", "name"}
+	candidates := c.Complete("SELECT  FROM employees", "SELECT ")
+	require.Contains(t, candidates, "name")
+	// a partly typed name is not a mapping, so its columns are not loaded
+	c.Complete("SELECT  FROM employ", "SELECT ")
+	require.Empty(t, c.loading)
+	require.NotContains(t, c.columns, "employ")
+}
+
+func TestSQLCompleter_WaitsAfterErrors(t *testing.T) {
+	c := newSQLCompleter(nil)
+	c.mappingsState = loading
+	c.setMappings(c.generation, nil, errors.New("not connected"))
+	require.Equal(t, notLoaded, c.mappingsState)
+	// the loads do not start again right away
+	require.Nil(t, c.Mappings())
+	require.Equal(t, notLoaded, c.mappingsState)
+	c.mappings = []string{"employees"}
+	require.Nil(t, c.Columns("employees"))
+	require.Empty(t, c.loading)
+	c.Invalidate()
+	require.True(t, c.canLoad())
+}
@@ -0,0 +1,269 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/format"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/viewer"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
+)
+
+// rowDetail shows a row of the result vertically, a column on each line, pretty-printing the JSON values.
+type rowDetail struct {
+	columns []string
+	row     []interface{}
+	// index is the position of the row in the shown rows
+	index, total int
+	// selected is the column whose value is copied as the cell
+	selected int
+	// offset is the first line in view
+	offset int
+}
+
+// detailLine is a line of the detail pane, a value may take many lines.
+type detailLine struct {
+	column int
+	text   string
+}
+
+// detailValue returns the text of the value, JSON values are indented.
+func detailValue(v interface{}) string {
+	if v == nil {
+		return "NULL"
+	}
+	s := format.Fmt(v)
+	if pretty, err := viewer.FormatJson(s); err == nil {
+		return pretty
+	}
+	return s
+}
+
+// lines lays out the columns and their values in width, wrapping the long values.
+func (d *rowDetail) lines(width int) []detailLine {
+	nameWidth := 0
+	for _, c := range d.columns {
+		nameWidth = max(nameWidth, lipgloss.Width(c))
+	}
+	nameWidth = min(nameWidth, width/3)
+	valueWidth := max(width-nameWidth-3, 1)
+	var lines []detailLine
+	for i, c := range d.columns {
+		name := wrap.String(c, nameWidth)
+		if n := strings.Index(name, "\n"); n >= 0 {
+			name = name[:n]
+		}
+		prefix := " " + name + strings.Repeat(" ", nameWidth-lipgloss.Width(name)) + ": "
+		value := wrap.String(wordwrap.String(detailValue(d.row[i]), valueWidth), valueWidth)
+		for j, l := range strings.Split(value, "\n") {
+			if j > 0 {
+				prefix = strings.Repeat(" ", nameWidth+3)
+			}
+			lines = append(lines, detailLine{column: i, text: prefix + l})
+		}
+	}
+	return lines
+}
+
+// columnLines returns the first and the last line of the column.
+func columnLines(lines []detailLine, column int) (int, int) {
+	first, last := -1, -1
+	for i, l := range lines {
+		if l.column != column {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+	}
+	return first, last
+}
+
+// scroll handles the navigation keys in a pane of the given size. Up and down scroll through a long value
+// before moving to the previous or the next column.
+func (d *rowDetail) scroll(key string, width, height int) {
+	lines := d.lines(width)
+	first, last := columnLines(lines, d.selected)
+	switch key {
+	case "up", "k", "w":
+		if first < d.offset {
+			d.offset--
+			return
+		}
+		if d.selected > 0 {
+			d.selected--
+			first, _ = columnLines(lines, d.selected)
+			d.offset = min(d.offset, first)
+		}
+	case "down", "j", "s":
+		if last >= d.offset+height {
+			d.offset++
+			return
+		}
+		if d.selected < len(d.columns)-1 {
+			d.selected++
+			first, last = columnLines(lines, d.selected)
+			if last >= d.offset+height {
+				d.offset = min(first, last-height+1)
+			}
+		}
+	case "pgdown":
+		d.offset = max(min(d.offset+height, len(lines)-height), 0)
+		d.selected = lines[d.offset].column
+	case "pgup":
+		d.offset = max(d.offset-height, 0)
+		d.selected = lines[d.offset].column
+	}
+}
+
+func (d *rowDetail) view(width, height int) string {
+	title := fmt.Sprintf(" Row %d/%d - esc: back  y: copy value  r: copy row  v: copy result", d.index+1, d.total)
+	titleStyle := viewer.HeaderStyle.Copy().Width(width)
+	selectedStyle := lipgloss.NewStyle().Bold(true)
+	if !tuiutil.Ascii {
+		selectedStyle = selectedStyle.Foreground(tuiutil.Highlight())
+	}
+	rows := []string{titleStyle.Render(title)}
+	lines := d.lines(width)
+	for i := d.offset; i < len(lines) && len(rows) < height; i++ {
+		if lines[i].column == d.selected {
+			rows = append(rows, selectedStyle.Render(lines[i].text))
+			continue
+		}
+		rows = append(rows, lines[i].text)
+	}
+	for len(rows) < height {
+		rows = append(rows, "")
+	}
+	return strings.Join(rows, "\n")
+}
+
+// copiedMsg is the outcome of copying to the clipboard.
+type copiedMsg struct {
+	what string
+	err  error
+}
+
+func (m copiedMsg) summary() string {
+	if m.err != nil {
+		return fmt.Sprintf("Cannot copy the %s: %s", m.what, m.err)
+	}
+	return fmt.Sprintf("Copied the %s to the clipboard", m.what)
+}
+
+func copyCmd(what, text string, err error) tea.Cmd {
+	return func() tea.Msg {
+		if err != nil {
+			return copiedMsg{what: what, err: err}
+		}
+		return copiedMsg{what: what, err: clipboard.WriteAll(text)}
+	}
+}
+
+// selectedRow returns the row under the cursor with all the columns of the result, and its position in the shown rows.
+func (t *table) selectedRow() ([]interface{}, int, bool) {
+	i := t.termdbmsTable.Viewport.YOffset + t.termdbmsTable.GetRow()
+	if t.lastIteration == nil || t.termdbmsTable.UI.RenderSelection || i >= len(t.shown) {
+		return nil, 0, false
+	}
+	return t.shown[i], i, true
+}
+
+func (t *table) openDetail() {
+	row, i, ok := t.selectedRow()
+	if !ok {
+		return
+	}
+	column := t.termdbmsTable.GetVisibleColumnName()
+	selected := 0
+	for i, c := range t.lastIteration.columnNames {
+		if c == column {
+			selected = i
+		}
+	}
+	t.detail = &rowDetail{
+		columns:  t.lastIteration.columnNames,
+		row:      row,
+		index:    i,
+		total:    len(t.shown),
+		selected: selected,
+	}
+}
+
+// detailHeight is the height of the detail pane, which replaces the header and the rows of the table.
+func (t *table) detailHeight() int {
+	return t.termdbmsTable.Viewport.Height + viewer.HeaderHeight + 1
+}
+
+// updateDetail handles the keys of the detail pane.
+func (t *table) updateDetail(key string) tea.Cmd {
+	switch key {
+	case "esc", "enter", "q":
+		t.detail = nil
+		return nil
+	case "y", "r", "v":
+		return t.copy(key)
+	}
+	// the title takes a line
+	t.detail.scroll(key, t.termdbmsTable.Viewport.Width, t.detailHeight()-1)
+	return nil
+}
+
+// copyTarget returns the row and the column to copy, from the detail pane if it is open.
+func (t *table) copyTarget() ([]interface{}, string, bool) {
+	if t.detail != nil {
+		return t.detail.row, t.detail.columns[t.detail.selected], true
+	}
+	row, _, ok := t.selectedRow()
+	return row, t.termdbmsTable.GetVisibleColumnName(), ok
+}
+
+// copy copies the selected cell (y), the selected row as JSON (r) or the shown rows and columns as CSV (v).
+func (t *table) copy(key string) tea.Cmd {
+	if t.lastIteration == nil {
+		return nil
+	}
+	columns := t.lastIteration.columnNames
+	switch key {
+	case "y":
+		row, column, ok := t.copyTarget()
+		if !ok {
+			return nil
+		}
+		for i, c := range columns {
+			if c == column {
+				return copyCmd("value", detailValue(row[i]), nil)
+			}
+		}
+		return nil
+	case "r":
+		row, _, ok := t.copyTarget()
+		if !ok {
+			return nil
+		}
+		text, err := rowJSON(columns, row)
+		return copyCmd("row", text, err)
+	}
+	visible := t.grid.visibleColumns(columns)
+	index := make(map[string]int, len(columns))
+	for i, c := range columns {
+		index[c] = i
+	}
+	rows := make([][]interface{}, len(t.shown))
+	for r, row := range t.shown {
+		rows[r] = make([]interface{}, len(visible))
+		for i, c := range visible {
+			rows[r][i] = row[index[c]]
+		}
+	}
+	var b strings.Builder
+	err := writeCSV(&b, visible, rows)
+	return copyCmd(fmt.Sprintf("%d rows", len(rows)), b.String(), err)
+}
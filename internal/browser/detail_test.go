@@ -0,0 +1,82 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowDetail_Lines(t *testing.T) {
+	d := rowDetail{
+		columns: []string{"id", "info"},
+		row:     []interface{}{int64(1), serialization.JSON(`{"city":"London"}`)},
+	}
+	var texts []string
+	for _, l := range d.lines(40) {
+		texts = append(texts, l.text)
+	}
+	require.Equal(t, []string{
+		" id  : 1",
+		" info: {",
+		`           "city": "London"`,
+		"       }",
+	}, texts)
+}
+
+func TestRowDetail_Scroll(t *testing.T) {
+	d := rowDetail{
+		columns: []string{"a", "b", "c"},
+		row:     []interface{}{"x", strings.Repeat("long ", 10), nil},
+	}
+	// "b" takes 5 lines with width 16, the pane has 3 lines
+	require.Len(t, d.lines(16), 7)
+	d.scroll("down", 16, 3)
+	require.Equal(t, 1, d.selected)
+	require.Equal(t, 1, d.offset)
+	// scroll through the value of "b" before selecting "c"
+	for i := 0; i < 2; i++ {
+		d.scroll("down", 16, 3)
+		require.Equal(t, 1, d.selected)
+	}
+	require.Equal(t, 3, d.offset)
+	d.scroll("down", 16, 3)
+	require.Equal(t, 2, d.selected)
+	require.Equal(t, 4, d.offset)
+	d.scroll("down", 16, 3)
+	require.Equal(t, 2, d.selected)
+	d.scroll("up", 16, 3)
+	require.Equal(t, 1, d.selected)
+	require.Equal(t, 1, d.offset)
+	// the column on the first line is selected after a page scroll
+	d.scroll("pgup", 16, 3)
+	require.Equal(t, 0, d.offset)
+	require.Equal(t, 0, d.selected)
+}
+
+func TestRowJSON(t *testing.T) {
+	s, err := rowJSON(exportColumns, exportRows[0])
+	require.NoError(t, err)
+	require.Equal(t, `{"name": "Joe", "age": 22, "info": {"city":"London"}}`, s)
+}
+
+func TestTable_Detail(t *testing.T) {
+	tb := &table{}
+	tb.Init()
+	tb.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	tb.lastIteration = &SQLIterator{columnNames: gridColumns, rowsFinished: set, rows: gridRows}
+	tb.Update(NewRowsMessage{source: tb.lastIteration})
+	tb.Update(tea.KeyMsg{Type: tea.KeyTab})
+	tb.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	tb.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, tb.detail)
+	require.Equal(t, gridRows[1], tb.detail.row)
+	v := tb.View()
+	require.Contains(t, v, "Row 2/4")
+	require.Contains(t, v, " name: ann")
+	require.Equal(t, tb.detailHeight(), len(strings.Split(v, "\n")))
+	tb.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Nil(t, tb.detail)
+}
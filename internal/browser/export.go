@@ -0,0 +1,253 @@
+package browser
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/format"
+)
+
+// exportBatchSize is the number of rows fetched at once while draining the result for an export.
+const exportBatchSize = 1000
+
+type exportDoneMsg struct {
+	source *SQLIterator
+	path   string
+	// fetched is the number of the rows fetched for the export, which were not shown yet
+	fetched int
+	count   int
+	err     error
+}
+
+func (m exportDoneMsg) summary() string {
+	if m.err != nil {
+		return fmt.Sprintf("Cannot export to %s: %s", m.path, m.err)
+	}
+	return fmt.Sprintf("Exported %d rows to %s", m.count, m.path)
+}
+
+// exportFormat returns the format of the export file from its extension.
+func exportFormat(path string) (string, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		return "csv", nil
+	case ".json":
+		return "json", nil
+	case ".md", ".markdown":
+		return "markdown", nil
+	default:
+		return "", fmt.Errorf("unknown file extension %q, use .csv, .json or .md", ext)
+	}
+}
+
+// startExport reports whether an export of the result of the table can start, it is false while another one runs.
+func (t *table) startExport() bool {
+	if !atomic.CompareAndSwapInt32(&t.exporting, 0, 1) {
+		return false
+	}
+	atomic.StoreInt64(&t.exportedRows, 0)
+	return true
+}
+
+// exportProgress returns the number of the rows fetched by the running export, false if there is no export.
+func (t *table) exportProgress() (int64, bool) {
+	if atomic.LoadInt32(&t.exporting) == 0 {
+		return 0, false
+	}
+	return atomic.LoadInt64(&t.exportedRows), true
+}
+
+// exportCmd fetches the rows of the result which are not consumed yet and writes all the rows to path.
+// The export must be started with t.startExport.
+func exportCmd(t *table, si *SQLIterator, path string) tea.Cmd {
+	return func() tea.Msg {
+		defer atomic.StoreInt32(&t.exporting, 0)
+		f, err := exportFormat(path)
+		if err != nil {
+			return exportDoneMsg{source: si, path: path, err: err}
+		}
+		fetched := si.drain(func(n int) {
+			atomic.StoreInt64(&t.exportedRows, int64(n))
+		})
+		all := si.consumedRows()
+		if err := writeExport(path, f, si.columnNames, all); err != nil {
+			return exportDoneMsg{source: si, path: path, fetched: fetched, err: err}
+		}
+		return exportDoneMsg{source: si, path: path, fetched: fetched, count: len(all)}
+	}
+}
+
+func writeExport(path, f string, columns []string, rows [][]interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	switch f {
+	case "csv":
+		err = writeCSV(file, columns, rows)
+	case "json":
+		err = writeJSON(file, columns, rows)
+	default:
+		err = writeMarkdown(file, columns, rows)
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func writeCSV(w io.Writer, columns []string, rows [][]interface{}) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+	values := make([]string, len(columns))
+	for _, row := range rows {
+		for i, v := range row {
+			values[i] = format.Fmt(v)
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeJSON writes the rows as an array of objects, keeping the column order in the objects.
+func writeJSON(w io.Writer, columns []string, rows [][]interface{}) error {
+	keys, err := jsonKeys(columns)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString("[")
+	for r, row := range rows {
+		if r > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  ")
+		if err := writeJSONObject(&b, keys, row); err != nil {
+			return err
+		}
+	}
+	b.WriteString("\n]\n")
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// rowJSON returns the row as an object, keeping the column order in the object.
+func rowJSON(columns []string, row []interface{}) (string, error) {
+	keys, err := jsonKeys(columns)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := writeJSONObject(&b, keys, row); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func jsonKeys(columns []string) ([][]byte, error) {
+	keys := make([][]byte, len(columns))
+	for i, c := range columns {
+		k, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = k
+	}
+	return keys, nil
+}
+
+func writeJSONObject(b *strings.Builder, keys [][]byte, row []interface{}) error {
+	b.WriteString("{")
+	for i, v := range row {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		value, err := jsonValue(v)
+		if err != nil {
+			return err
+		}
+		b.Write(keys[i])
+		b.WriteString(": ")
+		b.Write(value)
+	}
+	b.WriteString("}")
+	return nil
+}
+
+func jsonValue(v interface{}) ([]byte, error) {
+	switch t := v.(type) {
+	case nil, bool, string, int8, int16, int32, int64, float32, float64:
+		return json.Marshal(t)
+	case serialization.JSON:
+		if json.Valid(t) {
+			return t, nil
+		}
+	}
+	return json.Marshal(format.Fmt(v))
+}
+
+func writeMarkdown(w io.Writer, columns []string, rows [][]interface{}) error {
+	var b strings.Builder
+	writeRow := func(values []string) {
+		b.WriteString("|")
+		for _, v := range values {
+			v = strings.ReplaceAll(v, "|", `\|`)
+			v = strings.ReplaceAll(v, "\n", " ")
+			b.WriteString(" " + v + " |")
+		}
+		b.WriteString("\n")
+	}
+	writeRow(columns)
+	b.WriteString(strings.Repeat("| --- ", len(columns)) + "|\n")
+	values := make([]string, len(columns))
+	for _, row := range rows {
+		for i, v := range row {
+			values[i] = format.Fmt(v)
+		}
+		writeRow(values)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// drain consumes the rest of the result into the rows of the iterator, and returns the number of the consumed rows.
+// progress is called with the number of the rows of the result consumed so far.
+func (si *SQLIterator) drain(progress func(n int)) int {
+	// wait for the rows being consumed for the table, so that the rows are kept in the order of the result
+	si.consuming <- struct{}{}
+	defer func() { <-si.consuming }()
+	total := len(si.consumedRows())
+	progress(total)
+	var fetched int
+	si.startIterating(exportBatchSize)
+	for !si.finished() {
+		select {
+		case row, ok := <-si.resultPipe:
+			if !ok {
+				atomic.StoreInt32(&si.rowsFinished, set)
+				break
+			}
+			si.addConsumedRows([][]interface{}{row})
+			fetched++
+			progress(total + fetched)
+		case <-si.iterated:
+			si.startIterating(exportBatchSize)
+		}
+	}
+	return fetched
+}
@@ -0,0 +1,118 @@
+package browser
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/sql"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	exportColumns = []string{"name", "age", "info"}
+	exportRows    = [][]interface{}{
+		{"Joe", int32(22), serialization.JSON(`{"city":"London"}`)},
+		{"a|b", nil, nil},
+	}
+)
+
+func TestExportFormat(t *testing.T) {
+	for path, f := range map[string]string{"r.csv": "csv", "r.JSON": "json", "dir/r.md": "markdown"} {
+		got, err := exportFormat(path)
+		require.NoError(t, err)
+		require.Equal(t, f, got)
+	}
+	_, err := exportFormat("r.txt")
+	require.Error(t, err)
+}
+
+func TestTable_StartExport(t *testing.T) {
+	tb := &table{}
+	_, ok := tb.exportProgress()
+	require.False(t, ok)
+	require.True(t, tb.startExport())
+	// a second export of the table does not start while the first one runs
+	require.False(t, tb.startExport())
+	n, ok := tb.exportProgress()
+	require.True(t, ok)
+	require.Zero(t, n)
+	// the export ends even if it fails
+	msg := exportCmd(tb, nil, "result.txt")().(exportDoneMsg)
+	require.Error(t, msg.err)
+	_, ok = tb.exportProgress()
+	require.False(t, ok)
+	// the exports of the other tables are independent
+	require.True(t, (&table{}).startExport())
+	require.True(t, tb.startExport())
+}
+
+func TestWriteExport(t *testing.T) {
+	var b bytes.Buffer
+	require.NoError(t, writeCSV(&b, exportColumns, exportRows))
+	require.Equal(t, "name,age,info\nJoe,22,\"{\"\"city\"\":\"\"London\"\"}\"\na|b,<nil>,<nil>\n", b.String())
+	b.Reset()
+	require.NoError(t, writeJSON(&b, exportColumns, exportRows))
+	require.Equal(t, `[
+  {"name": "Joe", "age": 22, "info": {"city":"London"}},
+  {"name": "a|b", "age": null, "info": null}
+]
+`, b.String())
+	b.Reset()
+	require.NoError(t, writeMarkdown(&b, exportColumns, exportRows))
+	require.Equal(t, `| name | age | info |
+| --- | --- | --- |
+| Joe | 22 | {"city":"London"} |
+| a\|b | <nil> | <nil> |
+`, b.String())
+}
+
+// countingIterator returns the rows 0 to n-1 with a single column.
+type countingIterator struct {
+	sql.RowMetadata
+	next, n int
+}
+
+func (it *countingIterator) HasNext() bool { return it.next < it.n }
+func (it *countingIterator) Next() (sql.Row, error) {
+	it.next++
+	return countingRow{it, it.next - 1}, nil
+}
+func (it *countingIterator) ColumnCount() int                     { return 1 }
+func (r countingRow) Get(int) (interface{}, error)                { return r.value, nil }
+func (r countingRow) Metadata() sql.RowMetadata                   { return r.it }
+func (r countingRow) GetByColumnName(string) (interface{}, error) { return r.value, nil }
+
+type countingRow struct {
+	it    *countingIterator
+	value int
+}
+
+func TestSQLIterator_Drain(t *testing.T) {
+	const n = 2*exportBatchSize + 10
+	si := &SQLIterator{
+		it:         &countingIterator{n: n},
+		resultPipe: make(chan []interface{}, 51),
+		consuming:  make(chan struct{}, 1),
+		iterated:   make(chan struct{}, 1),
+	}
+	si.startIterating(50)
+	// the rows are consumed for the table while draining
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		si.ConsumeRowsCmd(10 * time.Millisecond)()
+	}()
+	var progress int
+	fetched := si.drain(func(n int) { progress = n })
+	<-consumed
+	rows := si.consumedRows()
+	require.True(t, si.finished())
+	require.Len(t, rows, n)
+	require.Equal(t, n, progress)
+	require.LessOrEqual(t, fetched, n)
+	for i, row := range rows {
+		require.Equal(t, []interface{}{i}, row)
+	}
+}
@@ -0,0 +1,342 @@
+package browser
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client/types"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/viewer"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
+)
+
+type sortOrder int
+
+const (
+	unsorted sortOrder = iota
+	ascending
+	descending
+)
+
+// columnFilter keeps the rows whose value in the column contains the text, or matches the pattern.
+type columnFilter struct {
+	text    string
+	pattern *regexp.Regexp
+}
+
+// newColumnFilter parses the filter value, a value surrounded with slashes is a regular expression.
+// Otherwise, the value is a case-insensitive substring.
+func newColumnFilter(value string) (*columnFilter, error) {
+	if len(value) > 1 && strings.HasPrefix(value, "/") && strings.HasSuffix(value, "/") {
+		re, err := regexp.Compile(value[1 : len(value)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return &columnFilter{text: value, pattern: re}, nil
+	}
+	return &columnFilter{text: value}, nil
+}
+
+func (f *columnFilter) match(v interface{}) bool {
+	s := viewer.GetStringRepresentationOfInterface(v)
+	if f.pattern != nil {
+		return f.pattern.MatchString(s)
+	}
+	return strings.Contains(strings.ToLower(s), strings.ToLower(f.text))
+}
+
+// grid is the client-side view of a result: the rows are filtered and sorted, and some columns are hidden.
+// It does not change the rows of the result, which are kept for exporting.
+type grid struct {
+	sortColumn string
+	order      sortOrder
+	filters    map[string]*columnFilter
+	hidden     map[string]bool
+}
+
+// cycleSort sorts by the column ascending, then descending, then in the order of the result.
+func (g *grid) cycleSort(column string) {
+	if g.sortColumn != column {
+		g.sortColumn = column
+		g.order = ascending
+		return
+	}
+	g.order = (g.order + 1) % 3
+	if g.order == unsorted {
+		g.sortColumn = ""
+	}
+}
+
+// setFilter filters the column with the value, an empty value removes the filter of the column.
+func (g *grid) setFilter(column, value string) error {
+	if value == "" {
+		delete(g.filters, column)
+		return nil
+	}
+	f, err := newColumnFilter(value)
+	if err != nil {
+		return err
+	}
+	if g.filters == nil {
+		g.filters = map[string]*columnFilter{}
+	}
+	g.filters[column] = f
+	return nil
+}
+
+func (g *grid) filterOf(column string) string {
+	if f, ok := g.filters[column]; ok {
+		return f.text
+	}
+	return ""
+}
+
+// hide hides the column, the last visible column cannot be hidden.
+func (g *grid) hide(columns []string, column string) bool {
+	if len(g.visibleColumns(columns)) <= 1 {
+		return false
+	}
+	if g.hidden == nil {
+		g.hidden = map[string]bool{}
+	}
+	g.hidden[column] = true
+	return true
+}
+
+func (g *grid) showAll() {
+	g.hidden = nil
+}
+
+// sorted reports whether the rows are reordered, so that the new rows of the result cannot be just appended.
+func (g *grid) sorted() bool {
+	return g.order != unsorted
+}
+
+// filtered reports whether some of the rows may be left out.
+func (g *grid) filtered() bool {
+	return len(g.filters) > 0
+}
+
+func (g *grid) visibleColumns(columns []string) []string {
+	var visible []string
+	for _, c := range columns {
+		if !g.hidden[c] {
+			visible = append(visible, c)
+		}
+	}
+	return visible
+}
+
+// rows returns the rows which pass the filters, sorted if a sort column is set.
+func (g *grid) rows(columns []string, rows [][]interface{}) [][]interface{} {
+	index := make(map[string]int, len(columns))
+	for i, c := range columns {
+		index[c] = i
+	}
+	result := rows
+	if g.filtered() {
+		result = nil
+	next:
+		for _, row := range rows {
+			for c, f := range g.filters {
+				if i, ok := index[c]; ok && !f.match(row[i]) {
+					continue next
+				}
+			}
+			result = append(result, row)
+		}
+	}
+	i, ok := index[g.sortColumn]
+	if !g.sorted() || !ok {
+		return result
+	}
+	result = append([][]interface{}{}, result...)
+	sort.SliceStable(result, func(a, b int) bool {
+		if g.order == descending {
+			return compareValues(result[b][i], result[a][i]) < 0
+		}
+		return compareValues(result[a][i], result[b][i]) < 0
+	})
+	return result
+}
+
+// compareValues compares the values of a column, numbers and times are compared by their value.
+// NULL is smaller than any value, values of different types are compared by their text.
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if x, ok := numberOf(a); ok {
+		if y, ok := numberOf(b); ok {
+			return x.Cmp(y)
+		}
+	}
+	if x, ok := timeOf(a); ok {
+		if y, ok := timeOf(b); ok {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			}
+			return 0
+		}
+	}
+	if x, ok := a.(bool); ok {
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(viewer.GetStringRepresentationOfInterface(a), viewer.GetStringRepresentationOfInterface(b))
+}
+
+func numberOf(v interface{}) (*big.Rat, bool) {
+	r := new(big.Rat)
+	switch t := v.(type) {
+	case int8:
+		return r.SetInt64(int64(t)), true
+	case int16:
+		return r.SetInt64(int64(t)), true
+	case int32:
+		return r.SetInt64(int64(t)), true
+	case int64:
+		return r.SetInt64(t), true
+	case float32:
+		return floatNumber(float64(t))
+	case float64:
+		return floatNumber(t)
+	case *big.Int:
+		if t == nil {
+			return nil, false
+		}
+		return r.SetInt(t), true
+	case types.Decimal:
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(t.Scale())), nil)
+		return r.SetFrac(t.UnscaledValue(), scale), true
+	}
+	return nil, false
+}
+
+// floatNumber converts finite floats only, infinities and NaN are compared by their text.
+func floatNumber(f float64) (*big.Rat, bool) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return nil, false
+	}
+	return new(big.Rat).SetFloat64(f), true
+}
+
+func timeOf(v interface{}) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case types.LocalDate:
+		return time.Time(t), true
+	case types.LocalTime:
+		return time.Time(t), true
+	case types.LocalDateTime:
+		return time.Time(t), true
+	case types.OffsetDateTime:
+		return time.Time(t), true
+	}
+	return time.Time{}, false
+}
+
+// updateGrid handles the keys which sort, hide and pin the columns of the result. It reports whether the key is handled.
+func (t *table) updateGrid(key string) bool {
+	if t.lastIteration == nil || t.termdbmsTable.UI.RenderSelection {
+		return false
+	}
+	columns := t.lastIteration.columnNames
+	column := t.termdbmsTable.GetVisibleColumnName()
+	switch key {
+	case "o":
+		t.grid.cycleSort(column)
+		t.scrollToTop()
+	case "x":
+		if !t.grid.hide(columns, column) {
+			return true
+		}
+	case "X":
+		t.grid.showAll()
+	case "p":
+		// pin the columns up to the selected one, and show them from the start
+		for i, c := range t.grid.visibleColumns(columns) {
+			if c == column {
+				t.termdbmsTable.UI.PinnedColumns = i + 1
+				t.termdbmsTable.Scroll.ScrollXOffset = 0
+				t.termdbmsTable.MouseData.X = i * t.termdbmsTable.CellWidth()
+				break
+			}
+		}
+	case "P":
+		t.termdbmsTable.UI.PinnedColumns = 0
+	default:
+		return false
+	}
+	t.refreshGrid()
+	return true
+}
+
+// setFilter filters the column of the result with the value, see newColumnFilter.
+func (t *table) setFilter(column, value string) error {
+	if err := t.grid.setFilter(column, value); err != nil {
+		return err
+	}
+	t.autoFetches = 0
+	t.scrollToTop()
+	t.refreshGrid()
+	return nil
+}
+
+// refreshGrid shows the rows of the result again, after the sort order, the filters or the columns change.
+func (t *table) refreshGrid() {
+	t.PopulateDataForResult(t.grid.rows(t.lastIteration.columnNames, t.receivedRows()), true)
+	t.termdbmsTable.Data().ColumnMarkers = t.grid.markers()
+	_ = t.termdbmsTable.NumHeaders() // to set maxHeaders global var, for side effect
+	t.termdbmsTable.SetViewSlices()
+	// keep the cursor on a column if the columns in view are fewer now
+	cw := t.termdbmsTable.CellWidth()
+	if n := len(t.termdbmsTable.Data().TableHeadersSlice); n > 0 && t.termdbmsTable.MouseData.X/cw >= n {
+		t.termdbmsTable.MouseData.X = (n - 1) * cw
+	}
+}
+
+func (t *table) scrollToTop() {
+	t.termdbmsTable.Viewport.YOffset = 0
+	t.termdbmsTable.MouseData.Y = viewer.HeaderHeight
+}
+
+// markers returns the marks shown after the column names, for the sort order and the filters.
+func (g *grid) markers() map[string]string {
+	up, down := " ↑", " ↓"
+	if tuiutil.Ascii {
+		up, down = " ^", " v"
+	}
+	markers := map[string]string{}
+	for c := range g.filters {
+		markers[c] = " ~"
+	}
+	switch g.order {
+	case ascending:
+		markers[g.sortColumn] += up
+	case descending:
+		markers[g.sortColumn] += down
+	}
+	return markers
+}
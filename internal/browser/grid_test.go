@@ -0,0 +1,144 @@
+package browser
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/termdbms/viewer"
+)
+
+var (
+	gridColumns = []string{"name", "age"}
+	gridRows    = [][]interface{}{
+		{"Joe", int32(22)},
+		{"ann", int32(9)},
+		{"Bob", nil},
+		{"jim", int32(100)},
+	}
+)
+
+func names(rows [][]interface{}) []interface{} {
+	var names []interface{}
+	for _, r := range rows {
+		names = append(names, r[0])
+	}
+	return names
+}
+
+func TestGrid_Sort(t *testing.T) {
+	var g grid
+	g.cycleSort("age")
+	require.Equal(t, []interface{}{"Bob", "ann", "Joe", "jim"}, names(g.rows(gridColumns, gridRows)))
+	g.cycleSort("age")
+	require.Equal(t, []interface{}{"jim", "Joe", "ann", "Bob"}, names(g.rows(gridColumns, gridRows)))
+	g.cycleSort("age")
+	require.False(t, g.sorted())
+	require.Equal(t, names(gridRows), names(g.rows(gridColumns, gridRows)))
+	// sorting by another column starts ascending
+	g.cycleSort("age")
+	g.cycleSort("name")
+	require.Equal(t, []interface{}{"Bob", "Joe", "ann", "jim"}, names(g.rows(gridColumns, gridRows)))
+}
+
+func TestGrid_Filter(t *testing.T) {
+	var g grid
+	require.NoError(t, g.setFilter("name", "J"))
+	require.Equal(t, []interface{}{"Joe", "jim"}, names(g.rows(gridColumns, gridRows)))
+	require.NoError(t, g.setFilter("age", "/^\\d{2}$/"))
+	require.Equal(t, []interface{}{"Joe"}, names(g.rows(gridColumns, gridRows)))
+	require.Equal(t, "/^\\d{2}$/", g.filterOf("age"))
+	require.Error(t, g.setFilter("age", "/(/"))
+	require.Equal(t, "/^\\d{2}$/", g.filterOf("age"))
+	require.NoError(t, g.setFilter("name", ""))
+	require.NoError(t, g.setFilter("age", "/^\\d+$/"))
+	require.Equal(t, []interface{}{"Joe", "ann", "jim"}, names(g.rows(gridColumns, gridRows)))
+	require.Equal(t, map[string]string{"age": " ~"}, g.markers())
+}
+
+func TestGrid_Hide(t *testing.T) {
+	var g grid
+	require.True(t, g.hide(gridColumns, "name"))
+	require.Equal(t, []string{"age"}, g.visibleColumns(gridColumns))
+	// the last column stays visible
+	require.False(t, g.hide(gridColumns, "age"))
+	require.Equal(t, []string{"age"}, g.visibleColumns(gridColumns))
+	g.showAll()
+	require.Equal(t, gridColumns, g.visibleColumns(gridColumns))
+}
+
+func TestCompareValues(t *testing.T) {
+	now := time.Now()
+	tcs := []struct {
+		a, b interface{}
+	}{
+		{nil, int64(-5)},
+		{int8(2), int64(10)},
+		{float64(1.5), int32(2)},
+		{types.NewDecimal(big.NewInt(125), 2), float32(1.3)},
+		{types.LocalDate(now), types.LocalDate(now.Add(48 * time.Hour))},
+		{false, true},
+		{"B", "a"},
+	}
+	for _, tc := range tcs {
+		require.Equal(t, -1, compareValues(tc.a, tc.b), "%v < %v", tc.a, tc.b)
+		require.Equal(t, 1, compareValues(tc.b, tc.a), "%v > %v", tc.b, tc.a)
+	}
+	require.Equal(t, 0, compareValues(int32(3), float64(3)))
+}
+
+func TestScrolledHeaders(t *testing.T) {
+	headers := []string{"a", "b", "c", "d", "e", "f"}
+	require.Equal(t, []string{"c", "d", "e"}, viewer.ScrolledHeaders(headers, 3, 0, 2))
+	require.Equal(t, []string{"a", "d", "e"}, viewer.ScrolledHeaders(headers, 3, 1, 2))
+	// the offset cannot scroll past the last header
+	require.Equal(t, []string{"a", "b", "e", "f"}, viewer.ScrolledHeaders(headers, 4, 2, 10))
+	// at least one column scrolls
+	require.Equal(t, []string{"a", "b", "f"}, viewer.ScrolledHeaders(headers, 3, 5, 10))
+}
+
+func TestTable_GridKeys(t *testing.T) {
+	tb := &table{}
+	tb.Init()
+	tb.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	tb.lastIteration = &SQLIterator{columnNames: gridColumns, rowsFinished: set, rows: gridRows}
+	tb.Update(NewRowsMessage{source: tb.lastIteration})
+	tb.Update(tea.KeyMsg{Type: tea.KeyTab})
+	key := func(k string) {
+		tb.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+	// select the age column and sort by it
+	key("d")
+	require.Equal(t, "age", tb.termdbmsTable.GetVisibleColumnName())
+	key("o")
+	key("o")
+	require.Equal(t, []interface{}{"jim", "Joe", "ann", "Bob"}, tb.termdbmsTable.GetSchemaData()["name"])
+	require.NoError(t, tb.setFilter("name", "j"))
+	require.Equal(t, []interface{}{int32(100), int32(22)}, tb.termdbmsTable.GetSchemaData()["age"])
+	require.Equal(t, " ~", tb.termdbmsTable.Data().ColumnMarkers["name"])
+	key("x")
+	require.Equal(t, []string{"name"}, tb.termdbmsTable.GetHeaders())
+	require.Equal(t, "name", tb.termdbmsTable.GetVisibleColumnName())
+	key("X")
+	require.Equal(t, gridColumns, tb.termdbmsTable.GetHeaders())
+}
+
+func TestTable_AutoFetchesAreCapped(t *testing.T) {
+	tb := &table{}
+	tb.Init()
+	tb.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	// the result is not finished, as if it is streaming
+	tb.lastIteration = &SQLIterator{columnNames: gridColumns, rows: gridRows}
+	require.NoError(t, tb.setFilter("name", "nobody"))
+	for i := 0; i < 2*maxAutoFetches; i++ {
+		tb.Update(NewRowsMessage{source: tb.lastIteration})
+	}
+	require.Equal(t, maxAutoFetches, tb.autoFetches)
+	// a new filter may fill the view, so the rows are fetched for it again
+	require.NoError(t, tb.setFilter("name", "j"))
+	require.Zero(t, tb.autoFetches)
+}
@@ -0,0 +1,94 @@
+package browser
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/file"
+)
+
+// savedQueriesFile is the name of the saved query library in the CLC home directory.
+const savedQueriesFile = "saved-queries.yaml"
+
+type savedQuery struct {
+	Name  string `yaml:"name"`
+	Query string `yaml:"query"`
+}
+
+// library is the list of the saved queries, in the order they are first saved.
+type library struct {
+	// path is blank if the saved queries cannot be loaded, so that they are not overwritten
+	path    string
+	loadErr error
+	queries []savedQuery
+}
+
+func loadLibrary(path string) (*library, error) {
+	l := &library{path: path}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+	if err == nil {
+		if err = yaml.Unmarshal(b, &l.queries); err != nil {
+			err = fmt.Errorf("parsing saved queries in %s: %w", path, err)
+		}
+	} else {
+		err = fmt.Errorf("reading saved queries: %w", err)
+	}
+	if err != nil {
+		l.path = ""
+		l.queries = nil
+		l.loadErr = err
+	}
+	return l, err
+}
+
+// save saves the query under the name, replacing the query saved with the same name.
+func (l *library) save(name, query string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("query name cannot be empty")
+	}
+	if l.path == "" {
+		return fmt.Errorf("the saved queries are not overwritten since they could not be loaded: %w", l.loadErr)
+	}
+	replaced := false
+	for i, q := range l.queries {
+		if q.Name == name {
+			l.queries[i].Query = query
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		l.queries = append(l.queries, savedQuery{Name: name, Query: query})
+	}
+	b, err := yaml.Marshal(l.queries)
+	if err != nil {
+		return err
+	}
+	return file.CreateMissingDirsAndFileWithRWPerms(l.path, b)
+}
+
+func (l *library) get(name string) (string, bool) {
+	for _, q := range l.queries {
+		if q.Name == strings.TrimSpace(name) {
+			return q.Query, true
+		}
+	}
+	return "", false
+}
+
+// names returns the names of the saved queries.
+func (l *library) names() []string {
+	names := make([]string, len(l.queries))
+	for i, q := range l.queries {
+		names[i] = q.Name
+	}
+	return names
+}
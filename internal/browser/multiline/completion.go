@@ -0,0 +1,77 @@
+package multiline
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSuggestions is the number of suggestions shown in the completion popup.
+const maxSuggestions = 8
+
+// Completer returns the candidates for the word being typed, before is the statement text up to that word.
+// The candidates are filtered by the word, so that the Completer only has to consider the context.
+type Completer func(statement, before string) []string
+
+// completion is the state of the completion popup.
+type completion struct {
+	items    []string
+	selected int
+}
+
+func (c completion) visible() bool {
+	return len(c.items) > 0
+}
+
+// suggest returns the candidates which start with word, ignoring case. The word itself is not suggested.
+func suggest(candidates []string, word string) []string {
+	if word == "" {
+		return nil
+	}
+	lw := strings.ToLower(word)
+	seen := make(map[string]struct{})
+	var items []string
+	for _, c := range candidates {
+		lc := strings.ToLower(c)
+		if lc == lw || !strings.HasPrefix(lc, lw) {
+			continue
+		}
+		if _, ok := seen[c]; ok {
+			continue
+		}
+		seen[c] = struct{}{}
+		items = append(items, c)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return len(items[i]) < len(items[j])
+	})
+	if len(items) > maxSuggestions {
+		items = items[:maxSuggestions]
+	}
+	return items
+}
+
+// completionText adapts keyword suggestions to the case of the typed word, so that lowercase queries stay lowercase.
+func completionText(item, word string) string {
+	if word != "" && strings.ToLower(word) == word && strings.ToUpper(item) == item {
+		return strings.ToLower(item)
+	}
+	return item
+}
+
+func (c completion) view() string {
+	selected := lipgloss.NewStyle().Reverse(true)
+	var b strings.Builder
+	for i, item := range c.items {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		if i == c.selected {
+			b.WriteString(selected.Render(" " + item + " "))
+			continue
+		}
+		b.WriteString(" " + item + " ")
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package multiline
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"SELECT", "SET", "SHOW", "employees", "SET"}
+	require.Equal(t, []string{"SET", "SHOW", "SELECT"}, suggest(candidates, "s"))
+	require.Equal(t, []string{"SELECT"}, suggest(candidates, "Sel"))
+	// the typed word is not suggested again
+	require.Empty(t, suggest(candidates, "show"))
+	require.Empty(t, suggest(candidates, ""))
+}
+
+func TestModel_Completion(t *testing.T) {
+	var statements []string
+	completer := func(statement, before string) []string {
+		statements = append(statements, before)
+		return []string{"SELECT", "FROM", "employees"}
+	}
+	var m tea.Model = *InitTextArea(&History{}, completer)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("se")})
+	require.Contains(t, m.View(), " SELECT ")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	require.Equal(t, "select", m.(model).textInput.Value())
+	require.True(t, m.(model).keyboardFocus)
+	for _, r := range " * from emp" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	require.Equal(t, "select * from employees", m.(model).textInput.Value())
+	require.Equal(t, "select * from ", statements[len(statements)-1])
+	// Tab toggles the focus when there are no suggestions
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	require.False(t, m.(model).keyboardFocus)
+}
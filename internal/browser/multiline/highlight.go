@@ -0,0 +1,178 @@
+package multiline
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers/s"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
+)
+
+// tokenKind is the highlighting class of a rune in the text input.
+type tokenKind int
+
+const (
+	kindPlain tokenKind = iota
+	kindKeyword
+	kindString
+	kindNumber
+	kindComment
+	kindIdentifier
+)
+
+var brackets = map[rune]rune{
+	'(': ')',
+	'[': ']',
+	'{': '}',
+}
+
+// highlightStyles returns the style of each token kind, following the selected theme.
+func highlightStyles() map[tokenKind]lipgloss.Style {
+	return map[tokenKind]lipgloss.Style{
+		kindPlain:      lipgloss.NewStyle(),
+		kindKeyword:    lipgloss.NewStyle().Foreground(tuiutil.Highlight()).Bold(true),
+		kindString:     lipgloss.NewStyle().Foreground(tuiutil.FooterForeground()),
+		kindNumber:     lipgloss.NewStyle().Foreground(tuiutil.HeaderForeground()),
+		kindComment:    lipgloss.NewStyle().Faint(true).Italic(true),
+		kindIdentifier: lipgloss.NewStyle().Foreground(tuiutil.TextColor()),
+	}
+}
+
+// matchStyle marks the bracket or quote under the cursor and its pair, it does not use colors.
+var matchStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+
+// tokenKinds classifies each rune of the lines with the SQL lexer. The whole text is tokenized at once,
+// so that comments and strings spanning multiple lines are classified correctly.
+func tokenKinds(lines [][]rune) [][]tokenKind {
+	kinds := make([][]tokenKind, len(lines))
+	for i, l := range lines {
+		kinds[i] = make([]tokenKind, len(l))
+	}
+	var text []string
+	for _, l := range lines {
+		text = append(text, string(l))
+	}
+	it, err := s.SQL.Tokenise(nil, strings.Join(text, "\n"))
+	if err != nil {
+		return kinds
+	}
+	var row, col int
+	for t := it(); t != chroma.EOF; t = it() {
+		k := kindOf(t.Type)
+		for _, r := range t.Value {
+			if r == '\n' {
+				row++
+				col = 0
+				continue
+			}
+			if row < len(kinds) && col < len(kinds[row]) {
+				kinds[row][col] = k
+			}
+			col++
+		}
+	}
+	return kinds
+}
+
+func kindOf(t chroma.TokenType) tokenKind {
+	switch {
+	case t.InCategory(chroma.Keyword):
+		return kindKeyword
+	case t.InCategory(chroma.Comment):
+		return kindComment
+	case t.InSubCategory(chroma.LiteralString):
+		return kindString
+	case t.InSubCategory(chroma.LiteralNumber):
+		return kindNumber
+	case t.InCategory(chroma.Name):
+		return kindIdentifier
+	}
+	return kindPlain
+}
+
+// position is the location of a rune in the text input.
+type position struct {
+	x, y int
+}
+
+// matchingPair returns the position of the bracket or quote at or just before the cursor, and of its pair.
+func matchingPair(lines [][]rune, cursor position) (position, position, bool) {
+	for _, p := range []position{cursor, {cursor.x - 1, cursor.y}} {
+		if p.x < 0 || p.x >= len(lines[p.y]) {
+			continue
+		}
+		if m, ok := findPair(lines, p); ok {
+			return p, m, true
+		}
+	}
+	return position{}, position{}, false
+}
+
+func findPair(lines [][]rune, p position) (position, bool) {
+	r := lines[p.y][p.x]
+	if r == '\'' || r == '"' {
+		return findQuotePair(lines[p.y], p, r)
+	}
+	if closing, ok := brackets[r]; ok {
+		return scanPair(lines, p, r, closing, 1)
+	}
+	for opening, closing := range brackets {
+		if r == closing {
+			return scanPair(lines, p, closing, opening, -1)
+		}
+	}
+	return position{}, false
+}
+
+// scanPair finds the pair of the bracket at p, scanning forward (dir 1) or backward (dir -1) and skipping nested pairs.
+func scanPair(lines [][]rune, p position, self, pair rune, dir int) (position, bool) {
+	depth := 0
+	x, y := p.x, p.y
+	for {
+		x += dir
+		for x < 0 || x >= len(lines[y]) {
+			y += dir
+			if y < 0 || y >= len(lines) {
+				return position{}, false
+			}
+			if dir > 0 {
+				x = 0
+			} else {
+				x = len(lines[y]) - 1
+			}
+		}
+		switch lines[y][x] {
+		case self:
+			depth++
+		case pair:
+			if depth == 0 {
+				return position{x, y}, true
+			}
+			depth--
+		}
+	}
+}
+
+// findQuotePair pairs the quotes of the line in order, which is enough since literals rarely span lines.
+func findQuotePair(line []rune, p position, quote rune) (position, bool) {
+	open := -1
+	for x, r := range line {
+		if r != quote {
+			continue
+		}
+		if open < 0 {
+			open = x
+			continue
+		}
+		if open == p.x {
+			return position{x, p.y}, true
+		}
+		if x == p.x {
+			return position{open, p.y}, true
+		}
+		open = -1
+	}
+	return position{}, false
+}
@@ -0,0 +1,40 @@
+package multiline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenKinds(t *testing.T) {
+	lines := [][]rune{[]rune("SELECT 'a', 42"), []rune("-- done")}
+	kinds := tokenKinds(lines)
+	require.Equal(t, kindKeyword, kinds[0][0])
+	require.Equal(t, kindString, kinds[0][7])
+	require.Equal(t, kindNumber, kinds[0][12])
+	require.Equal(t, kindComment, kinds[1][3])
+}
+
+func TestMatchingPair(t *testing.T) {
+	lines := [][]rune{[]rune("count((a)"), []rune(") + 'x'")}
+	tcs := []struct {
+		name   string
+		cursor position
+		pair   position
+		ok     bool
+	}{
+		{name: "opening bracket under the cursor", cursor: position{5, 0}, pair: position{0, 1}, ok: true},
+		{name: "closing bracket before the cursor", cursor: position{9, 0}, pair: position{6, 0}, ok: true},
+		{name: "closing quote", cursor: position{6, 1}, pair: position{4, 1}, ok: true},
+		{name: "no bracket", cursor: position{2, 0}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, pair, ok := matchingPair(lines, tc.cursor)
+			require.Equal(t, tc.ok, ok)
+			if ok {
+				require.Equal(t, tc.pair, pair)
+			}
+		})
+	}
+}
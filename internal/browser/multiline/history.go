@@ -0,0 +1,181 @@
+package multiline
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// maxHistorySize is the number of the most recent queries kept in memory.
+	maxHistorySize = 1000
+	// maxHistoryFileLines is the number of lines after which the history file is rewritten with the queries in memory.
+	// It is larger than maxHistorySize, so that the file is not rewritten on every query.
+	maxHistoryFileLines = 2 * maxHistorySize
+)
+
+// History is the list of the executed queries, the oldest first.
+// Queries may span multiple lines, so each one is stored as a JSON string per line of the history file.
+type History struct {
+	path    string
+	entries []string
+	// fileLines is the number of lines in the history file
+	fileLines int
+	// index is the entry shown in the text area while navigating, len(entries) when not navigating
+	index int
+	// draft is the text which was being edited before navigating the history
+	draft string
+}
+
+// LoadHistory reads the history at path, the file is created on the first Add if it does not exist.
+// If an error is returned, the returned History is still usable but kept only in memory.
+func LoadHistory(path string) (*History, error) {
+	h := &History{path: path}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		h.path = ""
+		return h, fmt.Errorf("opening history file: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		h.fileLines++
+		var q string
+		if err := json.Unmarshal(scanner.Bytes(), &q); err != nil {
+			// skip the corrupted entries
+			continue
+		}
+		h.entries = append(h.entries, q)
+	}
+	if len(h.entries) > maxHistorySize {
+		h.entries = h.entries[len(h.entries)-maxHistorySize:]
+	}
+	h.index = len(h.entries)
+	if err := scanner.Err(); err != nil {
+		return h, fmt.Errorf("reading history file: %w", err)
+	}
+	if h.fileLines > maxHistoryFileLines {
+		return h, h.compact()
+	}
+	return h, nil
+}
+
+// Add appends the query to the history and stops the navigation. Repeating the last query is not recorded again.
+func (h *History) Add(q string) error {
+	q = strings.TrimSpace(q)
+	defer h.Reset()
+	if q == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == q) {
+		return nil
+	}
+	h.entries = append(h.entries, q)
+	if len(h.entries) > maxHistorySize {
+		h.entries = h.entries[1:]
+	}
+	if h.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), os.ModePerm); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+	f, err := os.OpenFile(h.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("opening history file: %w", err)
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing history file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	h.fileLines++
+	if h.fileLines > maxHistoryFileLines {
+		return h.compact()
+	}
+	return nil
+}
+
+// compact replaces the history file with the queries in memory, so that the file does not grow without limit.
+func (h *History) compact() error {
+	var b []byte
+	for _, q := range h.entries {
+		line, err := json.Marshal(q)
+		if err != nil {
+			return err
+		}
+		b = append(append(b, line...), '\n')
+	}
+	// write to a temporary file first, so that the history is not lost if writing fails
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return fmt.Errorf("compacting history file: %w", err)
+	}
+	if err := os.Rename(tmp, h.path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("compacting history file: %w", err)
+	}
+	h.fileLines = len(h.entries)
+	return nil
+}
+
+// Reset stops the navigation, the next Older call returns the most recent query.
+func (h *History) Reset() {
+	h.index = len(h.entries)
+	h.draft = ""
+}
+
+// Older returns the query before the current one. current is the text being edited, which is restored by Newer
+// after the most recent query.
+func (h *History) Older(current string) (string, bool) {
+	if h.index == 0 {
+		return "", false
+	}
+	if h.index == len(h.entries) {
+		h.draft = current
+	}
+	h.index--
+	return h.entries[h.index], true
+}
+
+// Newer returns the query after the current one, or the text being edited before the navigation started.
+func (h *History) Newer() (string, bool) {
+	if h.index >= len(h.entries) {
+		return "", false
+	}
+	h.index++
+	if h.index == len(h.entries) {
+		return h.draft, true
+	}
+	return h.entries[h.index], true
+}
+
+// Search returns the most recent query containing term, which is older than the query at before.
+// Pass the size of the history as before to search from the most recent query.
+func (h *History) Search(term string, before int) (int, string, bool) {
+	if before > len(h.entries) {
+		before = len(h.entries)
+	}
+	term = strings.ToLower(term)
+	for i := before - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(h.entries[i]), term) {
+			return i, h.entries[i], true
+		}
+	}
+	return 0, "", false
+}
+
+// Len returns the number of queries in the history.
+func (h *History) Len() int {
+	return len(h.entries)
+}
@@ -0,0 +1,98 @@
+package multiline
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_Persists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "sql-history")
+	h, err := LoadHistory(path)
+	require.NoError(t, err)
+	require.NoError(t, h.Add("select 1"))
+	require.NoError(t, h.Add("select *\nfrom m"))
+	// repeating the last query is not recorded
+	require.NoError(t, h.Add("select *\nfrom m"))
+	h, err = LoadHistory(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"select 1", "select *\nfrom m"}, h.entries)
+}
+
+func TestHistory_Compact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+	h, err := LoadHistory(path)
+	require.NoError(t, err)
+	for i := 0; i <= maxHistoryFileLines; i++ {
+		require.NoError(t, h.Add(fmt.Sprintf("select %d", i)))
+	}
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, maxHistorySize, bytes.Count(b, []byte("\n")))
+	h, err = LoadHistory(path)
+	require.NoError(t, err)
+	require.Equal(t, maxHistorySize, h.Len())
+	require.Equal(t, fmt.Sprintf("select %d", maxHistoryFileLines), h.entries[h.Len()-1])
+}
+
+func TestHistory_Navigation(t *testing.T) {
+	h := &History{}
+	require.NoError(t, h.Add("q1"))
+	require.NoError(t, h.Add("q2"))
+	q, ok := h.Older("draft")
+	require.True(t, ok)
+	require.Equal(t, "q2", q)
+	q, _ = h.Older("q2")
+	require.Equal(t, "q1", q)
+	_, ok = h.Older("q1")
+	require.False(t, ok)
+	q, _ = h.Newer()
+	require.Equal(t, "q2", q)
+	q, ok = h.Newer()
+	require.True(t, ok)
+	require.Equal(t, "draft", q)
+	_, ok = h.Newer()
+	require.False(t, ok)
+}
+
+func TestHistory_Search(t *testing.T) {
+	h := &History{}
+	for _, q := range []string{"SELECT * FROM a", "show mappings", "select * from b"} {
+		require.NoError(t, h.Add(q))
+	}
+	i, q, ok := h.Search("select", h.Len())
+	require.True(t, ok)
+	require.Equal(t, "select * from b", q)
+	_, q, ok = h.Search("select", i)
+	require.True(t, ok)
+	require.Equal(t, "SELECT * FROM a", q)
+	_, _, ok = h.Search("select", 0)
+	require.False(t, ok)
+}
+
+func TestModel_SearchOverlay(t *testing.T) {
+	h := &History{}
+	require.NoError(t, h.Add("select * from a"))
+	require.NoError(t, h.Add("show mappings"))
+	var m tea.Model = *InitTextArea(h, nil)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("draft")})
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlR},
+		{Type: tea.KeyRunes, Runes: []rune("sel")},
+	} {
+		m, _ = m.Update(msg)
+	}
+	require.Equal(t, "select * from a", m.(model).textInput.Value())
+	require.Contains(t, m.View(), "(reverse-i-search)`sel'")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	require.Equal(t, "draft", m.(model).textInput.Value())
+	require.False(t, m.(model).search.active)
+	// up on the first line recalls the most recent query
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	require.Equal(t, "show mappings", m.(model).textInput.Value())
+}
@@ -1,15 +1,22 @@
 package multiline
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
 )
 
 type SubmitMsg string
 
+// ExplainMsg requests the execution plan of the statement instead of running it.
+type ExplainMsg string
+
 // to create a distance between right most border of text box and terminal border
 const multilineTextBoxRightPadding = 5
 
@@ -18,19 +25,47 @@ type model struct {
 	err           error
 	ready         bool
 	keyboardFocus bool
+	history       *History
+	search        historySearch
+	completer     Completer
+	completion    completion
+}
+
+// historySearch is the state of the incremental reverse search started with Ctrl+R.
+type historySearch struct {
+	active bool
+	term   string
+	// match is the index of the matched query in the history, history size if there is no match
+	match  int
+	failed bool
+	// draft is the text which was being edited before the search started
+	draft string
 }
 
-func InitTextArea() *model {
+func InitTextArea(history *History, completer Completer) *model {
 	ti := New()
 	ti.Placeholder = "sql query here"
+	ti.Highlight = lipgloss.ColorProfile() != termenv.Ascii && tuiutil.SelectedTheme != tuiutil.NoColor
 	ti.Focus()
 	return &model{
 		textInput:     ti,
 		err:           nil,
 		keyboardFocus: true,
+		history:       history,
+		completer:     completer,
 	}
 }
 
+// SetValue replaces the text in the editor.
+func (m *model) SetValue(s string) {
+	m.textInput.SetValue(s)
+}
+
+// Value returns the text in the editor.
+func (m model) Value() string {
+	return m.textInput.Value()
+}
+
 func (m model) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -38,6 +73,27 @@ func (m model) Init() tea.Cmd {
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch tmsg := msg.(type) {
 	case tea.KeyMsg:
+		// the popup is shown again below if the key changes the word being typed
+		c := m.completion
+		m.completion = completion{}
+		if m.keyboardFocus && c.visible() {
+			switch tmsg.Type {
+			case tea.KeyTab:
+				word := m.textInput.WordBeforeCursor()
+				m.textInput.ReplaceWordBeforeCursor(completionText(c.items[c.selected], word))
+				return m, nil
+			case tea.KeyDown:
+				c.selected = (c.selected + 1) % len(c.items)
+				m.completion = c
+				return m, nil
+			case tea.KeyUp:
+				c.selected = (c.selected + len(c.items) - 1) % len(c.items)
+				m.completion = c
+				return m, nil
+			case tea.KeyEsc:
+				return m, nil
+			}
+		}
 		if tmsg.Type == tea.KeyTab {
 			var teaCmd tea.Cmd
 			if m.keyboardFocus {
@@ -51,8 +107,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if !m.keyboardFocus {
 			return m, nil
 		}
+		if m.search.active {
+			if handled := m.updateSearch(tmsg); handled {
+				return m, nil
+			}
+		}
 		switch tmsg.Type {
+		case tea.KeyCtrlR:
+			m.search = historySearch{active: true, match: m.history.Len(), draft: m.textInput.Value()}
+			return m, nil
+		case tea.KeyUp:
+			if m.textInput.CursorLine() == 0 {
+				if q, ok := m.history.Older(m.textInput.Value()); ok {
+					m.textInput.SetValue(q)
+					return m, nil
+				}
+			}
+		case tea.KeyDown:
+			if m.textInput.CursorLine() == m.textInput.LineCount()-1 {
+				if q, ok := m.history.Newer(); ok {
+					m.textInput.SetValue(q)
+					return m, nil
+				}
+			}
 		case tea.KeyCtrlE:
+			// errors are ignored, the history is kept in memory if it cannot be saved
+			_ = m.history.Add(m.textInput.Value())
 			submitQueryCmd := func() tea.Msg {
 				if statement := m.textInput.Value(); strings.Trim(statement, " ") != "" {
 					return SubmitMsg(statement)
@@ -60,6 +140,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return nil
 			}
 			return m, submitQueryCmd
+		case tea.KeyCtrlX:
+			return m, func() tea.Msg {
+				if statement := m.textInput.Value(); strings.Trim(statement, " ") != "" {
+					return ExplainMsg(statement)
+				}
+				return nil
+			}
 		}
 	case tea.WindowSizeMsg:
 		m.textInput.Width = tmsg.Width - multilineTextBoxRightPadding
@@ -67,13 +154,100 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	var cmd1 tea.Cmd
 	m.textInput, cmd1 = m.textInput.Update(msg)
+	if k, ok := msg.(tea.KeyMsg); ok && (k.Type == tea.KeyRunes || k.Type == tea.KeyBackspace) {
+		m.updateCompletion()
+	}
 	return m, tea.Batch(cmd1)
 }
 
+func (m *model) updateCompletion() {
+	if m.completer == nil {
+		return
+	}
+	word := m.textInput.WordBeforeCursor()
+	if word == "" {
+		return
+	}
+	before := m.textInput.TextBeforeCursor()
+	before = before[:len(before)-len(word)]
+	m.completion = completion{items: suggest(m.completer(m.textInput.Value(), before), word)}
+}
+
+// updateSearch handles the key while searching the history, and reports whether the key is consumed.
+// Keys other than the search keys accept the match and are handled as usual.
+func (m *model) updateSearch(msg tea.KeyMsg) bool {
+	switch msg.Type {
+	case tea.KeyCtrlR:
+		m.searchHistory(m.search.match)
+	case tea.KeyRunes:
+		m.search.term += string(msg.Runes)
+		m.searchHistory(m.history.Len())
+	case tea.KeySpace:
+		m.search.term += " "
+		m.searchHistory(m.history.Len())
+	case tea.KeyBackspace:
+		if r := []rune(m.search.term); len(r) > 0 {
+			m.search.term = string(r[:len(r)-1])
+		}
+		m.searchHistory(m.history.Len())
+	case tea.KeyEsc, tea.KeyCtrlG:
+		m.textInput.SetValue(m.search.draft)
+		m.search = historySearch{}
+	case tea.KeyEnter:
+		m.search = historySearch{}
+		m.history.Reset()
+	default:
+		m.search = historySearch{}
+		m.history.Reset()
+		return false
+	}
+	return true
+}
+
+func (m *model) searchHistory(before int) {
+	if m.search.term == "" {
+		m.search.failed = false
+		return
+	}
+	i, q, ok := m.history.Search(m.search.term, before)
+	m.search.failed = !ok
+	if !ok {
+		return
+	}
+	m.search.match = i
+	m.textInput.SetValue(q)
+}
+
 func (m model) View() string {
 	content := m.textInput.View()
+	if m.search.active {
+		prompt := "reverse-i-search"
+		if m.search.failed {
+			prompt = "failing " + prompt
+		}
+		searchLine := lipgloss.NewStyle().Reverse(true).Render(fmt.Sprintf("(%s)`%s'", prompt, m.search.term))
+		content = m.withLine(content, searchLine, true)
+	} else if m.completion.visible() {
+		content = m.withLine(content, m.completion.view(), false)
+	}
 	if !m.keyboardFocus {
 		content = lipgloss.NewStyle().Faint(true).Render(content)
 	}
 	return content
 }
+
+// withLine adds line to the top or bottom of content, dropping a line of content if it does not fit the height.
+func (m model) withLine(content, line string, top bool) string {
+	lines := strings.Split(content, "\n")
+	if m.textInput.Height > 0 && len(lines) >= m.textInput.Height {
+		if top {
+			lines = lines[:m.textInput.Height-1]
+		} else {
+			lines = lines[len(lines)-m.textInput.Height+1:]
+		}
+	}
+	if top {
+		return strings.Join(append([]string{line}, lines...), "\n")
+	}
+	return strings.Join(append(lines, line), "\n")
+}
@@ -118,6 +118,8 @@ type Model struct {
 	// viewport. If 0 or less this setting is ignored.
 	Width  int
 	Height int
+	// Highlight enables SQL syntax highlighting, brackets and quotes are matched regardless.
+	Highlight bool
 	// The ID of this Model as it relates to other textinput Models.
 	id int
 	// The ID of the blink message we're expecting to receive.
@@ -175,17 +177,15 @@ func New() Model {
 func (m *Model) SetValue(s string) {
 	lines := strings.Split(s, "\n")
 	runes := make([][]rune, len(lines))
-	for _, l := range lines {
-		runes = append(runes, []rune(l))
+	for i, l := range lines {
+		runes[i] = []rune(l)
 	}
 	if m.CharLimit > 0 && len(runes) > m.CharLimit {
 		m.value = runes[:m.CharLimit]
 	} else {
 		m.value = runes
 	}
-	m.posY = len(m.value[len(m.value)-1])
-	m.posY = len(m.value)
-	m.handleOverflow()
+	m.setCursor(len(m.value[len(m.value)-1]), len(m.value)-1)
 }
 
 // Value returns the value of the text input.
@@ -202,6 +202,51 @@ func (m Model) Cursor() int {
 	return m.pos
 }
 
+// CursorLine returns the line of the cursor.
+func (m Model) CursorLine() int {
+	return m.posY
+}
+
+// LineCount returns the number of lines of the text input.
+func (m Model) LineCount() int {
+	return len(m.value)
+}
+
+// TextBeforeCursor returns the text from the start of the input up to the cursor.
+func (m Model) TextBeforeCursor() string {
+	var b strings.Builder
+	for _, l := range m.value[:m.posY] {
+		b.WriteString(string(l))
+		b.WriteString("\n")
+	}
+	b.WriteString(string(m.value[m.posY][:m.pos]))
+	return b.String()
+}
+
+// WordBeforeCursor returns the identifier characters directly before the cursor.
+func (m Model) WordBeforeCursor() string {
+	line := m.value[m.posY]
+	start := m.pos
+	for start > 0 && isWordRune(line[start-1]) {
+		start--
+	}
+	return string(line[start:m.pos])
+}
+
+// ReplaceWordBeforeCursor replaces the identifier characters directly before the cursor with s.
+func (m *Model) ReplaceWordBeforeCursor(s string) {
+	n := len([]rune(m.WordBeforeCursor()))
+	line := m.value[m.posY]
+	tail := append([]rune(nil), line[m.pos:]...)
+	line = append(append(line[:m.pos-n], []rune(s)...), tail...)
+	m.value[m.posY] = line
+	m.setCursor(m.pos-n+len([]rune(s)), m.posY)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
 // SetCursor moves the cursor to the given position. If the position is
 // out of bounds the cursor will be moved to the start or end accordingly.
 func (m *Model) SetCursor(pos, posY int) {
@@ -701,25 +746,29 @@ func (m Model) View() string {
 		return m.placeholderView()
 	}
 	styleText := m.TextStyle.Inline(true).Render
+	r := m.newRenderer()
 	var toPrint []string
 	for i, l := range m.value[m.offsetTop:m.offsetBottom] {
 		var (
 			value []rune
 			pos   int
+			start int
 		)
-		if i+m.offsetTop == m.posY {
+		y := i + m.offsetTop
+		if y == m.posY {
 			value = l[m.offsetLeft:m.offsetRight]
 			pos = max(0, m.pos-m.offsetLeft)
+			start = m.offsetLeft
 		} else {
 			value = l
 			pos = len(l)
 		}
-		v := styleText(m.echoTransform(string(value[:pos])))
+		v := r.render(value[:pos], start, y)
 
-		if i+m.offsetTop == m.posY {
+		if y == m.posY {
 			if pos < len(value) {
 				v += m.cursorView(m.echoTransform(string(value[pos]))) // cursor and text under it
-				v += styleText(m.echoTransform(string(value[pos+1:]))) // text after cursor
+				v += r.render(value[pos+1:], start+pos+1, y)           // text after cursor
 			} else {
 				v += m.cursorView(" ")
 			}
@@ -747,6 +796,86 @@ func (m Model) View() string {
 	return m.PromptStyle.Render(m.Prompt) + lipgloss.JoinVertical(lipgloss.Left, toPrint...)
 }
 
+// renderer styles the runes of the text input for the view.
+type renderer struct {
+	m      *Model
+	kinds  [][]tokenKind
+	styles map[tokenKind]lipgloss.Style
+	// matched are the bracket or quote under the cursor and its pair
+	matched map[position]struct{}
+}
+
+func (m *Model) newRenderer() renderer {
+	r := renderer{m: m}
+	if m.EchoMode != EchoNormal {
+		return r
+	}
+	if m.Highlight {
+		r.kinds = tokenKinds(m.value)
+		r.styles = highlightStyles()
+	}
+	if m.focus {
+		if a, b, ok := matchingPair(m.value, position{m.pos, m.posY}); ok {
+			r.matched = map[position]struct{}{a: {}, b: {}}
+		}
+	}
+	return r
+}
+
+// render styles the runes which start at column x of line y, grouping the consecutive runes of the same style.
+func (r renderer) render(runes []rune, x, y int) string {
+	if r.kinds == nil && r.matched == nil {
+		return r.m.TextStyle.Inline(true).Render(r.m.echoTransform(string(runes)))
+	}
+	var b strings.Builder
+	var (
+		run    []rune
+		runKey styleKey
+	)
+	flush := func() {
+		if len(run) > 0 {
+			b.WriteString(r.style(runKey).Render(string(run)))
+			run = run[:0]
+		}
+	}
+	for i, c := range runes {
+		key := r.key(x+i, y)
+		if key != runKey {
+			flush()
+		}
+		runKey = key
+		run = append(run, c)
+	}
+	flush()
+	return b.String()
+}
+
+// styleKey identifies the style of a rune.
+type styleKey struct {
+	kind    tokenKind
+	matched bool
+}
+
+func (r renderer) key(x, y int) styleKey {
+	var k styleKey
+	if r.kinds != nil && y < len(r.kinds) && x < len(r.kinds[y]) {
+		k.kind = r.kinds[y][x]
+	}
+	_, k.matched = r.matched[position{x, y}]
+	return k
+}
+
+func (r renderer) style(k styleKey) lipgloss.Style {
+	style := r.m.TextStyle.Copy()
+	if r.styles != nil {
+		style = r.styles[k.kind].Copy().Inherit(r.m.TextStyle)
+	}
+	if k.matched {
+		style = matchStyle.Copy().Inherit(style)
+	}
+	return style.Inline(true)
+}
+
 // placeholderView returns the prompt and placeholder view, if any.
 func (m Model) placeholderView() string {
 	var (
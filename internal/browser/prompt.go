@@ -0,0 +1,128 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type promptKind int
+
+const (
+	promptExport promptKind = iota
+	promptSave
+	promptLoad
+	promptFilter
+)
+
+// linePrompt asks for a single value, such as a file path or a query name, in place of the help bar.
+type linePrompt struct {
+	kind   promptKind
+	active bool
+	input  textinput.Model
+	// hint is shown after the input, e.g. the names which can be typed
+	hint string
+	// status is the outcome of the last action, shown until the next key press
+	status string
+	// column is the result column that the filter prompt filters
+	column string
+}
+
+func (p *linePrompt) start(kind promptKind, label, placeholder, value, hint string) tea.Cmd {
+	p.kind = kind
+	p.input = textinput.New()
+	p.input.Prompt = label + ": "
+	p.input.Placeholder = placeholder
+	p.input.SetValue(value)
+	p.input.CursorEnd()
+	p.hint = hint
+	p.status = ""
+	p.active = true
+	return p.input.Focus()
+}
+
+func (c controller) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c.prompt.status = ""
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		c.prompt.active = false
+		return c, nil
+	case tea.KeyEnter:
+		value := strings.TrimSpace(c.prompt.input.Value())
+		if value == "" && c.prompt.kind != promptFilter {
+			return c, nil
+		}
+		return c.submitPrompt(value)
+	}
+	var cmd tea.Cmd
+	c.prompt.input, cmd = c.prompt.input.Update(msg)
+	return c, cmd
+}
+
+// submitPrompt runs the action of the prompt, the prompt stays open if the value is invalid.
+func (c controller) submitPrompt(value string) (tea.Model, tea.Cmd) {
+	switch c.prompt.kind {
+	case promptExport:
+		if _, err := exportFormat(value); err != nil {
+			c.prompt.status = err.Error()
+			return c, nil
+		}
+		t := c.tabs.current().table
+		if !t.startExport() {
+			c.prompt.status = "The result is being exported, wait until the export ends"
+			return c, nil
+		}
+		c.prompt.active = false
+		return c, exportCmd(t, t.lastIteration, value)
+	case promptSave:
+		t := c.tabs.current()
+		if err := c.library.save(value, t.editor.Value()); err != nil {
+			c.prompt.status = fmt.Sprintf("Cannot save the query: %s", err)
+			return c, nil
+		}
+		t.name = value
+		c.prompt.active = false
+		c.prompt.status = fmt.Sprintf("Saved the query as %s", value)
+		return c, nil
+	case promptFilter:
+		if err := c.tabs.current().table.setFilter(c.prompt.column, value); err != nil {
+			c.prompt.status = err.Error()
+			return c, nil
+		}
+		c.prompt.active = false
+		return c, nil
+	default:
+		q, ok := c.library.get(value)
+		if !ok {
+			c.prompt.status = fmt.Sprintf("There is no saved query named %s", value)
+			return c, nil
+		}
+		c.prompt.active = false
+		return c.addTab(value, q)
+	}
+}
+
+// View shows the prompt or the status of the last action in place of the help bar.
+func (c controller) View() string {
+	v := c.Model.View()
+	var parts []string
+	if c.prompt.active {
+		parts = append(parts, c.prompt.input.View())
+		if c.prompt.hint != "" && c.prompt.status == "" {
+			parts = append(parts, c.prompt.hint)
+		}
+	}
+	if c.prompt.status != "" {
+		parts = append(parts, c.prompt.status)
+	}
+	if len(parts) == 0 {
+		return v
+	}
+	line := strings.Join(parts, "  ")
+	if i := strings.LastIndex(v, "\n"); i >= 0 {
+		return v[:i+1] + line
+	}
+	return line
+}
@@ -34,6 +34,8 @@ func changeProgress(ps int32) {
 
 type SeparatorWithProgress struct {
 	length int
+	// tabs are read for the export progress of the active tab
+	tabs *tabSet
 }
 
 func (s *SeparatorWithProgress) Init() tea.Cmd {
@@ -51,7 +53,9 @@ func (s *SeparatorWithProgress) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (s *SeparatorWithProgress) View() string {
 	var baseMsg string
-	if atomic.LoadInt32(&progressState) == ShowProgress {
+	if n, ok := s.tabs.current().table.exportProgress(); ok {
+		baseMsg = fmt.Sprintf(" %s Exporting, %d rows fetched ", spinnerWidget.View(), n)
+	} else if atomic.LoadInt32(&progressState) == ShowProgress {
 		baseMsg = fmt.Sprintf(" %s Executing query ", spinnerWidget.View())
 	}
 	return strings.Repeat("─", max(0, s.length-len(baseMsg))) + baseMsg
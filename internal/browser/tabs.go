@@ -0,0 +1,201 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
+)
+
+// editor is the SQL editor of a tab.
+type editor interface {
+	tea.Model
+	Value() string
+}
+
+// tab is a query workspace with its own editor and result table.
+type tab struct {
+	name   string
+	table  *table
+	editor editor
+}
+
+func (t *tab) updateEditor(msg tea.Msg) tea.Cmd {
+	m, cmd := t.editor.Update(msg)
+	t.editor = m.(editor)
+	return cmd
+}
+
+// tabMsg is a result to be shown on the given tab, even if another tab is active when it arrives.
+type tabMsg struct {
+	tab *tab
+	msg tea.Msg
+}
+
+// tabSet is shared by the tab bar, the tabbed table and the tabbed editor components of the layout.
+type tabSet struct {
+	tabs    []*tab
+	active  int
+	created int
+	// newEditor creates an editor with the given text
+	newEditor func(text string) editor
+}
+
+func (ts *tabSet) current() *tab {
+	return ts.tabs[ts.active]
+}
+
+// add creates a tab with the given query in its editor and activates it.
+func (ts *tabSet) add(name, query string) tea.Cmd {
+	ts.created++
+	if name == "" {
+		name = fmt.Sprintf("Query %d", ts.created)
+	}
+	editor := ts.newEditor(query)
+	t := &tab{name: name, table: &table{}, editor: editor}
+	ts.tabs = append(ts.tabs, t)
+	ts.activate(len(ts.tabs) - 1)
+	return tea.Batch(t.table.Init(), editor.Init())
+}
+
+// close closes the active tab, canceling its query. The last tab is replaced with an empty one.
+func (ts *tabSet) close() tea.Cmd {
+	t := ts.current()
+	t.table.cancelQuery()
+	ts.tabs = append(ts.tabs[:ts.active], ts.tabs[ts.active+1:]...)
+	if len(ts.tabs) == 0 {
+		return ts.add("", "")
+	}
+	ts.activate(min(ts.active, len(ts.tabs)-1))
+	return nil
+}
+
+// move activates the tab which is delta tabs away from the active one, wrapping around.
+func (ts *tabSet) move(delta int) {
+	n := len(ts.tabs)
+	ts.activate(((ts.active+delta)%n + n) % n)
+}
+
+func (ts *tabSet) activate(i int) {
+	ts.active = i
+	// the faint flag is global to the viewer, it follows the focus of the active table
+	tuiutil.Faint = !ts.current().table.keyboardFocus
+}
+
+// tableOf returns the table which consumes the rows of the iterator, nil if the iterator is not the last one of any table.
+func (ts *tabSet) tableOf(si *SQLIterator) *table {
+	for _, t := range ts.tabs {
+		if t.table.lastIteration == si {
+			return t.table
+		}
+	}
+	return nil
+}
+
+// tabBar shows the names of the tabs, highlighting the active one.
+type tabBar struct {
+	set *tabSet
+}
+
+func (b tabBar) Init() tea.Cmd {
+	return nil
+}
+
+func (b tabBar) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return b, nil
+}
+
+func (b tabBar) View() string {
+	base := lipgloss.NewStyle().Foreground(tuiutil.FooterForeground())
+	active := base.Copy().Reverse(true).Bold(true)
+	var names []string
+	for i, t := range b.set.tabs {
+		name := fmt.Sprintf(" %d: %s ", i+1, t.name)
+		if i == b.set.active {
+			names = append(names, active.Render(name))
+			continue
+		}
+		names = append(names, base.Render(name))
+	}
+	return strings.Join(names, " ")
+}
+
+// tabbedTable shows the result table of the active tab.
+type tabbedTable struct {
+	set *tabSet
+}
+
+func (tt *tabbedTable) Init() tea.Cmd {
+	return nil
+}
+
+func (tt *tabbedTable) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch m := msg.(type) {
+	case tea.WindowSizeMsg:
+		var cmds []tea.Cmd
+		for _, t := range tt.set.tabs {
+			_, cmd := t.table.Update(m)
+			cmds = append(cmds, cmd)
+		}
+		return tt, tea.Batch(cmds...)
+	case tabMsg:
+		_, cmd := m.tab.table.Update(m.msg)
+		return tt, cmd
+	case NewRowsMessage:
+		return tt, tt.updateTableOf(m.source, msg)
+	case FetchMoreRowsMsg:
+		return tt, tt.updateTableOf(m.source, msg)
+	}
+	_, cmd := tt.set.current().table.Update(msg)
+	return tt, cmd
+}
+
+func (tt *tabbedTable) updateTableOf(si *SQLIterator, msg tea.Msg) tea.Cmd {
+	t := tt.set.tableOf(si)
+	if t == nil {
+		// the tab is closed or shows another result
+		return nil
+	}
+	_, cmd := t.Update(msg)
+	return cmd
+}
+
+func (tt *tabbedTable) View() string {
+	return tt.set.current().table.View()
+}
+
+// tabbedEditor shows the editor of the active tab.
+type tabbedEditor struct {
+	set *tabSet
+}
+
+func (te *tabbedEditor) Init() tea.Cmd {
+	return te.set.current().editor.Init()
+}
+
+func (te *tabbedEditor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		t := te.set.current()
+		return te, t.updateEditor(msg)
+	}
+	// window sizes and cursor blinks are for every editor, blinks are ignored by the other editors
+	var cmds []tea.Cmd
+	for _, t := range te.set.tabs {
+		cmds = append(cmds, t.updateEditor(msg))
+	}
+	return te, tea.Batch(cmds...)
+}
+
+func (te *tabbedEditor) View() string {
+	return te.set.current().editor.View()
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,85 @@
+package browser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/browser/multiline"
+)
+
+func newTestTabSet() *tabSet {
+	return &tabSet{
+		newEditor: func(text string) editor {
+			e := multiline.InitTextArea(&multiline.History{}, nil)
+			e.SetValue(text)
+			return e
+		},
+	}
+}
+
+func TestTabSet(t *testing.T) {
+	ts := newTestTabSet()
+	ts.add("", "")
+	ts.add("orders", "select * from orders")
+	require.Equal(t, 1, ts.active)
+	require.Equal(t, "orders", ts.current().name)
+	require.Equal(t, "select * from orders", ts.current().editor.Value())
+	ts.move(1)
+	require.Equal(t, "Query 1", ts.current().name)
+	ts.move(-1)
+	require.Equal(t, "orders", ts.current().name)
+	ts.close()
+	require.Len(t, ts.tabs, 1)
+	require.Equal(t, "Query 1", ts.current().name)
+	// closing the last tab opens an empty one
+	ts.close()
+	require.Len(t, ts.tabs, 1)
+	require.Equal(t, "Query 3", ts.current().name)
+}
+
+func TestTabbedEditor_KeysGoToActiveTab(t *testing.T) {
+	ts := newTestTabSet()
+	ts.add("", "")
+	ts.add("", "")
+	te := &tabbedEditor{set: ts}
+	te.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("select")})
+	require.Equal(t, "", ts.tabs[0].editor.Value())
+	require.Equal(t, "select", ts.tabs[1].editor.Value())
+}
+
+func TestLibrary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", savedQueriesFile)
+	l, err := loadLibrary(path)
+	require.NoError(t, err)
+	require.NoError(t, l.save("orders", "select * from orders"))
+	require.NoError(t, l.save("top", "select *\nfrom users"))
+	require.NoError(t, l.save("orders", "select * from orders where id > 10"))
+	require.Error(t, l.save(" ", "select 1"))
+	l, err = loadLibrary(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"orders", "top"}, l.names())
+	q, ok := l.get("orders")
+	require.True(t, ok)
+	require.Equal(t, "select * from orders where id > 10", q)
+	q, ok = l.get("top")
+	require.True(t, ok)
+	require.Equal(t, "select *\nfrom users", q)
+	_, ok = l.get("missing")
+	require.False(t, ok)
+}
+
+func TestLibrary_NotOverwrittenIfCannotBeLoaded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), savedQueriesFile)
+	corrupted := []byte("- name: orders\n  query: [select\n")
+	require.NoError(t, os.WriteFile(path, corrupted, 0600))
+	l, err := loadLibrary(path)
+	require.Error(t, err)
+	require.Error(t, l.save("top", "select 1"))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, corrupted, b)
+}
@@ -74,6 +74,9 @@ type CobraPrompt struct {
 	NoColor bool
 	// DisableSuggestions disables the suggestion prompt
 	DisableSuggestions bool
+	// InteractiveCommands returns the commands which are available only in the prompt,
+	// it is called for every input since the command chain is re-initialized
+	InteractiveCommands func() []*cobra.Command
 }
 
 var ErrExit = errors.New("exit prompt")
@@ -276,6 +279,9 @@ func prepareRootCmdForPrompt(co CobraPrompt, root *cobra.Command) {
 			},
 		})
 	}
+	if co.InteractiveCommands != nil {
+		root.AddCommand(co.InteractiveCommands()...)
+	}
 	root.Example = `> map put -k key -n myMap -v someValue
 > map get -k key -m myMap
 > cluster version
@@ -1,11 +1,15 @@
 package connection
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
 
@@ -16,89 +20,254 @@ import (
 
 var InvalidStateErr = errors.New("invalid new state")
 
+// restAttemptTimeout is the time to connect to a member, and to receive the response of a read-only operation,
+// before trying the next member.
+const restAttemptTimeout = 5 * time.Second
+
+const defaultMemberPort = "5701"
+
 type RESTCall struct {
 	url    string
 	params string
 }
 
-func CallClusterOperation(config *hazelcast.Config, operation string) (*string, error) {
+// ClusterOperationResult is the response of a cluster operation.
+type ClusterOperationResult struct {
+	// Member is the address of the member which answered.
+	Member string
+	// Unreachable are the addresses tried before Member, which did not answer.
+	Unreachable []string
+	Body        string
+}
+
+func CallClusterOperation(ctx context.Context, config *hazelcast.Config, operation string) (*ClusterOperationResult, error) {
 	var str string
-	return CallClusterOperationWithState(config, operation, &str)
+	return CallClusterOperationWithState(ctx, config, operation, &str)
 }
 
-func CallClusterOperationWithState(config *hazelcast.Config, operation string, state *string) (*string, error) {
-	obj, err := NewRESTCall(config, operation, *state)
-	if err != nil {
-		if errors.Is(err, InvalidStateErr) {
-			err = hzcerrors.NewLoggableError(err, "Invalid new state. It should be one the following: %s, %s, %s, %s\n", constants.ClusterStateActive, constants.ClusterStateFrozen, constants.ClusterStateNoMigration, constants.ClusterStatePassive)
+// RESTResponse is the JSON response of the cluster management REST API.
+type RESTResponse struct {
+	Status  string `json:"status"`
+	State   string `json:"state"`
+	Version string `json:"version"`
+	Message string `json:"message"`
+}
+
+// ParseRESTResponse parses the response body and returns an error if the operation did not succeed.
+func ParseRESTResponse(body string) (RESTResponse, error) {
+	var r RESTResponse
+	if err := json.Unmarshal([]byte(body), &r); err != nil {
+		return r, fmt.Errorf("unexpected response %q: %w", body, err)
+	}
+	switch r.Status {
+	case "success":
+		return r, nil
+	case "forbidden":
+		return r, errors.New("the cluster name or the password is not correct")
+	}
+	if r.Message != "" {
+		return r, fmt.Errorf("%s: %s", r.Status, r.Message)
+	}
+	return r, fmt.Errorf("the operation did not succeed: %s", r.Status)
+}
+
+// CallClusterOperationWithState calls the operation on the members returned by RESTAddresses in turn, until one
+// of them answers or ctx is done.
+func CallClusterOperationWithState(ctx context.Context, config *hazelcast.Config, operation string, state *string) (*ClusterOperationResult, error) {
+	result := &ClusterOperationResult{}
+	var lastErr error
+	for _, member := range RESTAddresses(config) {
+		if err := ctx.Err(); err != nil {
+			return nil, hzcerrors.NewLoggableError(err, "Could not call the cluster: %s", err)
 		}
-		return nil, err
+		body, err := CallMemberOperation(ctx, config, member, operation, *state)
+		if err == nil {
+			result.Member = member
+			result.Body = body
+			return result, nil
+		}
+		if errors.Is(err, InvalidStateErr) || !canRetry(operation, err) {
+			return nil, translateRESTError(config, operation, err)
+		}
+		result.Unreachable = append(result.Unreachable, member)
+		lastErr = err
 	}
-	params := obj.params
-	urlStr := obj.url
-	pr := strings.NewReader(params)
-	var resp *http.Response
-	tr := &http.Transport{
-		TLSClientConfig: config.Cluster.Network.SSL.TLSConfig(),
+	tried := strings.Join(result.Unreachable, ", ")
+	if msg, handled := hzcerrors.TranslateError(lastErr, config.Cluster.Cloud.Enabled, operation); handled {
+		return nil, hzcerrors.NewLoggableError(lastErr, "None of the members answered, tried %s. %s", tried, msg)
 	}
-	client := &http.Client{Transport: tr}
+	return nil, hzcerrors.NewLoggableError(lastErr, "None of the members answered, tried %s: %s", tried, lastErr)
+}
+
+// CallMemberOperation calls the operation on the member with the given address only, and returns the response body.
+// The argument is the new state of change-state and the log level of log-level-set, it is not used otherwise.
+// The call is abandoned when ctx is done.
+func CallMemberOperation(ctx context.Context, config *hazelcast.Config, member, operation, argument string) (string, error) {
+	obj, err := NewRESTCall(config, member, operation, argument)
+	if err != nil {
+		return "", err
+	}
+	client := newRESTClient(config, isReadOnly(operation))
+	var req *http.Request
 	switch operation {
-	case constants.ClusterGetState, constants.ClusterChangeState, constants.ClusterShutdown:
-		resp, err = client.Post(urlStr, "application/x-www-form-urlencoded", pr)
-	case constants.ClusterVersion:
-		resp, err = client.Get(urlStr)
+	case constants.ClusterGetState, constants.ClusterChangeState, constants.ClusterShutdown, constants.MemberShutdown,
+		constants.PersistenceForceStart, constants.PersistencePartialStart, constants.PersistenceBackup, constants.PersistenceBackupInterrupt,
+		constants.LogLevelSet, constants.LogLevelReset:
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, obj.url, strings.NewReader(obj.params))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	case constants.ClusterVersion, constants.ClusterLicense, constants.ClusterInfo:
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, obj.url, nil)
 	}
 	if err != nil {
-		if msg, handled := hzcerrors.TranslateError(err, config.Cluster.Cloud.Enabled, operation); handled {
-			return nil, hzcerrors.NewLoggableError(err, msg)
-		}
-		return nil, err
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("the member does not provide %s", obj.url)
+	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, hzcerrors.NewLoggableError(err, "Could not read the response from the cluster")
+		return "", hzcerrors.NewLoggableError(err, "Could not read the response from the cluster")
 	}
-	sb := string(body)
-	return &sb, nil
+	return string(body), nil
 }
 
-func NewRESTCall(conf *hazelcast.Config, operation string, state string) (*RESTCall, error) {
-	var member, url string
-	var params string
-	//todo iterate over all addresses
-	member = config.GetClusterAddress(conf)
-	scheme := "http"
-	if conf.Cluster.Network.SSL.Enabled == true {
-		scheme = "https"
+// newRESTClient returns a client which gives up connecting to a member after restAttemptTimeout. The read-only
+// operations must be answered within restAttemptTimeout too.
+func newRESTClient(config *hazelcast.Config, readOnly bool) *http.Client {
+	dialer := &net.Dialer{Timeout: restAttemptTimeout}
+	tr := &http.Transport{
+		TLSClientConfig: config.Cluster.Network.SSL.TLSConfig(),
+		DialContext:     dialer.DialContext,
 	}
+	client := &http.Client{Transport: tr}
+	if readOnly {
+		client.Timeout = restAttemptTimeout
+	}
+	return client
+}
+
+// restScheme returns the scheme of the member URLs.
+func restScheme(conf *hazelcast.Config) string {
+	if conf.Cluster.Network.SSL.Enabled {
+		return "https"
+	}
+	return "http"
+}
+
+func translateRESTError(config *hazelcast.Config, operation string, err error) error {
+	if errors.Is(err, InvalidStateErr) {
+		return hzcerrors.NewLoggableError(err, "Invalid new state. It should be one the following: %s, %s, %s, %s\n", constants.ClusterStateActive, constants.ClusterStateFrozen, constants.ClusterStateNoMigration, constants.ClusterStatePassive)
+	}
+	if msg, handled := hzcerrors.TranslateError(err, config.Cluster.Cloud.Enabled, operation); handled {
+		return hzcerrors.NewLoggableError(err, msg)
+	}
+	return err
+}
+
+func isReadOnly(operation string) bool {
+	switch operation {
+	case constants.ClusterGetState, constants.ClusterVersion, constants.ClusterLicense, constants.ClusterInfo:
+		return true
+	}
+	return false
+}
+
+// canRetry reports whether the operation can be called on another member after it failed with err. The operations
+// which change the cluster are retried only if the member could not be connected, since they may have been applied
+// otherwise.
+func canRetry(operation string, err error) bool {
+	var loggableErr hzcerrors.LoggableError
+	if errors.As(err, &loggableErr) {
+		return false
+	}
+	if isReadOnly(operation) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// RESTAddresses returns the addresses of the members to call the REST operations on: the configured addresses,
+// followed by the addresses of the members known to the client if it is connected.
+func RESTAddresses(conf *hazelcast.Config) []string {
+	candidates := conf.Cluster.Network.Addresses
+	if len(candidates) == 0 {
+		candidates = []string{config.GetClusterAddress(conf)}
+	}
+	for _, m := range knownMembers() {
+		candidates = append(candidates, m.Address.String())
+	}
+	var addresses []string
+	seen := map[string]bool{}
+	for _, a := range candidates {
+		if _, _, err := net.SplitHostPort(a); err != nil {
+			a = net.JoinHostPort(a, defaultMemberPort)
+		}
+		if !seen[a] {
+			seen[a] = true
+			addresses = append(addresses, a)
+		}
+	}
+	return addresses
+}
+
+func NewRESTCall(conf *hazelcast.Config, member, operation, state string) (*RESTCall, error) {
+	var url string
+	scheme := restScheme(conf)
 	switch operation {
 	case constants.ClusterGetState:
 		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterGetStateEndpoint)
 	case constants.ClusterChangeState:
-		if !validateState(state) {
+		if !ValidateState(state) {
 			return nil, InvalidStateErr
 		}
 		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterChangeStateEndpoint)
 	case constants.ClusterShutdown:
 		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterShutdownEndpoint)
+	case constants.MemberShutdown:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.MemberShutdownEndpoint)
 	case constants.ClusterVersion:
 		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterVersionEndpoint)
+	case constants.PersistenceForceStart:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ForceStartEndpoint)
+	case constants.PersistencePartialStart:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.PartialStartEndpoint)
+	case constants.PersistenceBackup:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.BackupEndpoint)
+	case constants.PersistenceBackupInterrupt:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.BackupInterruptEndpoint)
+	case constants.ClusterLicense:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterLicenseEndpoint)
+	case constants.ClusterInfo:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.ClusterInfoEndpoint)
+	case constants.LogLevelSet:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.LogLevelEndpoint)
+	case constants.LogLevelReset:
+		url = fmt.Sprintf("%s://%s%s", scheme, member, constants.LogLevelResetEndpoint)
 	default:
 		panic("Invalid operation to set connection obj.")
 	}
-	params = newParams(conf, operation, state)
+	params := newParams(conf, operation, state)
 	return &RESTCall{url: url, params: params}, nil
 }
 
 func newParams(config *hazelcast.Config, operation string, state string) string {
 	var params string
 	switch operation {
-	case constants.ClusterGetState, constants.ClusterShutdown:
+	case constants.ClusterGetState, constants.ClusterShutdown, constants.MemberShutdown,
+		constants.PersistenceForceStart, constants.PersistencePartialStart, constants.PersistenceBackup, constants.PersistenceBackupInterrupt,
+		constants.LogLevelReset:
 		params = fmt.Sprintf("%s&%s", config.Cluster.Name, config.Cluster.Security.Credentials.Password)
-	case constants.ClusterChangeState:
+	case constants.ClusterChangeState, constants.LogLevelSet:
 		params = fmt.Sprintf("%s&%s&%s", config.Cluster.Name, config.Cluster.Security.Credentials.Password, state)
-	case constants.ClusterVersion:
+	case constants.ClusterVersion, constants.ClusterLicense, constants.ClusterInfo:
 		params = ""
 	default:
 		panic("invalid operation to set params.")
@@ -106,7 +275,8 @@ func newParams(config *hazelcast.Config, operation string, state string) string
 	return params
 }
 
-func validateState(state string) bool {
+// ValidateState reports whether state is a cluster state which can be set.
+func ValidateState(state string) bool {
 	switch strings.ToLower(state) {
 	case constants.ClusterStateActive, constants.ClusterStateFrozen, constants.ClusterStateNoMigration, constants.ClusterStatePassive:
 		return true
@@ -0,0 +1,82 @@
+package connection
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+// downAddress returns an address nothing listens on.
+func downAddress(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	return addr
+}
+
+func restServer(t *testing.T, requests *[]string) string {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, r.URL.Path+"?"+string(body))
+		w.Write([]byte(`{"status":"success","state":"active"}`))
+	}))
+	t.Cleanup(s.Close)
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func TestCallClusterOperation_Failover(t *testing.T) {
+	var requests []string
+	down := downAddress(t)
+	up := restServer(t, &requests)
+	var c hazelcast.Config
+	c.Cluster.Name = "dev"
+	c.Cluster.Network.SetAddresses(down, up)
+	state := "frozen"
+	result, err := CallClusterOperationWithState(context.Background(), &c, constants.ClusterChangeState, &state)
+	require.NoError(t, err)
+	require.Equal(t, up, result.Member)
+	require.Equal(t, []string{down}, result.Unreachable)
+	require.Equal(t, `{"status":"success","state":"active"}`, result.Body)
+	require.Equal(t, []string{constants.ClusterChangeStateEndpoint + "?dev&&frozen"}, requests)
+}
+
+func TestCallClusterOperation_NoneAnswered(t *testing.T) {
+	var c hazelcast.Config
+	down1, down2 := downAddress(t), downAddress(t)
+	c.Cluster.Network.SetAddresses(down1, down2)
+	_, err := CallClusterOperation(context.Background(), &c, constants.ClusterGetState)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "None of the members answered, tried "+down1+", "+down2+".")
+	state := "unknown"
+	_, err = CallClusterOperationWithState(context.Background(), &c, constants.ClusterChangeState, &state)
+	require.ErrorIs(t, err, InvalidStateErr)
+}
+
+func TestCallMemberOperation_Canceled(t *testing.T) {
+	release := make(chan struct{})
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer s.Close()
+	defer close(release)
+	var c hazelcast.Config
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := CallMemberOperation(ctx, &c, strings.TrimPrefix(s.URL, "http://"), constants.ClusterChangeState, "frozen")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), restAttemptTimeout)
+	_, err = CallClusterOperation(ctx, &c, constants.ClusterGetState)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
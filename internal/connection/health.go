@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/constants"
+)
+
+// MemberHealth is the health of a member as reported by its health check endpoints.
+type MemberHealth struct {
+	Member             string `json:"member"`
+	NodeState          string `json:"nodeState"`
+	ClusterState       string `json:"clusterState"`
+	ClusterSafe        bool   `json:"clusterSafe"`
+	MigrationQueueSize int    `json:"migrationQueueSize"`
+	ClusterSize        int    `json:"clusterSize"`
+	Ready              bool   `json:"ready"`
+	// Error is set if the health of the member could not be retrieved, the other fields are not set then.
+	Error string `json:"error,omitempty"`
+}
+
+// Healthy reports whether the member is ready and the cluster is safe according to the member.
+func (h MemberHealth) Healthy() bool {
+	return h.Error == "" && h.Ready && h.ClusterSafe
+}
+
+// Problem returns why the member is not healthy, blank if it is healthy.
+func (h MemberHealth) Problem() string {
+	switch {
+	case h.Error != "":
+		return fmt.Sprintf("cannot check the health: %s", h.Error)
+	case !h.Ready:
+		return "not ready"
+	case !h.ClusterSafe:
+		return "cluster is not safe"
+	}
+	return ""
+}
+
+// GetMemberHealth queries the health check endpoints of the member. The health check must be enabled on the member,
+// e.g. with the hazelcast.http.healthcheck.enabled property.
+func GetMemberHealth(ctx context.Context, config *hazelcast.Config, member string) MemberHealth {
+	h := MemberHealth{Member: member}
+	client := newRESTClient(config, true)
+	status, body, err := restGet(ctx, client, fmt.Sprintf("%s://%s%s", restScheme(config), member, constants.ClusterHealthEndpoint))
+	if err != nil {
+		h.Error = err.Error()
+		return h
+	}
+	if status != http.StatusOK {
+		h.Error = fmt.Sprintf("health check responded with %s, it may be disabled on the member", http.StatusText(status))
+		return h
+	}
+	if err := json.Unmarshal(body, &h); err != nil {
+		h.Error = fmt.Sprintf("invalid health check response: %s", err)
+		return h
+	}
+	// the readiness endpoint answers without a body, a member which is not ready answers with 503
+	status, _, err = restGet(ctx, client, fmt.Sprintf("%s://%s%s", restScheme(config), member, constants.ClusterReadyEndpoint))
+	if err != nil {
+		h.Error = err.Error()
+		return h
+	}
+	h.Ready = status == http.StatusOK
+	return h
+}
+
+func restGet(ctx context.Context, client *http.Client, url string) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
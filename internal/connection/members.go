@@ -0,0 +1,134 @@
+//go:build !hazelcastinternal
+// +build !hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connection
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+// clusterView is the member list of the connected client. The member list is only exposed by the internal API of the
+// client, which is available when built with the hazelcastinternal tag, so otherwise it is kept up to date by a
+// membership listener registered before the client starts, which receives the initial members too.
+var clusterView = &struct {
+	members []cluster.MemberInfo
+	// received is closed when the first member is added, the events are delivered after the client starts
+	received chan struct{}
+	sync.Mutex
+}{received: make(chan struct{})}
+
+// Members returns the members of the cluster in the order they joined the client's view.
+// It waits until the initial member list is received.
+func Members(ctx context.Context) ([]cluster.MemberInfo, error) {
+	clusterView.Lock()
+	received := clusterView.received
+	clusterView.Unlock()
+	select {
+	case <-received:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for the member list: %w", ctx.Err())
+	}
+	clusterView.Lock()
+	defer clusterView.Unlock()
+	return append([]cluster.MemberInfo{}, clusterView.members...), nil
+}
+
+// MemberConnected reports whether the client is connected to the member, known is false since the connections are
+// only exposed by the internal API of the client.
+func MemberConnected(uuid types.UUID) (connected, known bool) {
+	return false, false
+}
+
+// knownMembers returns the members known to the client without waiting, none if the client is not connected.
+func knownMembers() []cluster.MemberInfo {
+	clusterView.Lock()
+	defer clusterView.Unlock()
+	return append([]cluster.MemberInfo{}, clusterView.members...)
+}
+
+func updateClusterView(event cluster.MembershipStateChanged) {
+	clusterView.Lock()
+	defer clusterView.Unlock()
+	clusterView.members = applyMembershipChange(clusterView.members, event)
+	if len(clusterView.members) > 0 {
+		select {
+		case <-clusterView.received:
+		default:
+			close(clusterView.received)
+		}
+	}
+}
+
+func applyMembershipChange(members []cluster.MemberInfo, event cluster.MembershipStateChanged) []cluster.MemberInfo {
+	for i, m := range members {
+		if m.UUID != event.Member.UUID {
+			continue
+		}
+		if event.State == cluster.MembershipStateRemoved {
+			return append(members[:i], members[i+1:]...)
+		}
+		members[i] = event.Member
+		return members
+	}
+	if event.State == cluster.MembershipStateAdded {
+		members = append(members, event.Member)
+	}
+	return members
+}
+
+func resetClusterView() {
+	clusterView.Lock()
+	defer clusterView.Unlock()
+	clusterView.members = nil
+	clusterView.received = make(chan struct{})
+}
+
+// withClusterView returns a copy of the config which keeps the cluster view up to date with the members of the client
+// started with it.
+func withClusterView(config *hazelcast.Config) hazelcast.Config {
+	resetClusterView()
+	c := copyWithoutListeners(config)
+	c.AddMembershipListener(updateClusterView)
+	return c
+}
+
+// copyWithoutListeners returns a deep copy of the config without its listeners. Unlike the copy returned by
+// config.Clone, it does not share the listeners with config, so that the listener of the cluster view is not added
+// to config again on every connection. The near cache configurations are restored from NearCaches on validation.
+func copyWithoutListeners(config *hazelcast.Config) hazelcast.Config {
+	c := config.Clone()
+	return hazelcast.Config{
+		NearCaches:            c.NearCaches,
+		FlakeIDGenerators:     c.FlakeIDGenerators,
+		Labels:                c.Labels,
+		ClientName:            c.ClientName,
+		Logger:                c.Logger,
+		Failover:              c.Failover,
+		Serialization:         c.Serialization,
+		Cluster:               c.Cluster,
+		Stats:                 c.Stats,
+		NearCacheInvalidation: c.NearCacheInvalidation,
+	}
+}
@@ -0,0 +1,66 @@
+//go:build hazelcastinternal
+// +build hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connection
+
+import (
+	"context"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+)
+
+// Members returns the members of the cluster in the order they joined the cluster.
+// The client receives the initial member list before it starts, so Members does not wait.
+func Members(ctx context.Context) ([]cluster.MemberInfo, error) {
+	return knownMembers(), nil
+}
+
+// MemberConnected reports whether the client is connected to the member, known is false if the client is not started.
+func MemberConnected(uuid types.UUID) (connected, known bool) {
+	c := startedClient()
+	if c == nil {
+		return false, false
+	}
+	return hazelcast.NewClientInternal(c).ConnectedToMember(uuid), true
+}
+
+// knownMembers returns the members known to the client, none if the client is not started.
+func knownMembers() []cluster.MemberInfo {
+	c := startedClient()
+	if c == nil {
+		return nil
+	}
+	return hazelcast.NewClientInternal(c).OrderedMembers()
+}
+
+func startedClient() *hazelcast.Client {
+	hzClient.Lock()
+	defer hzClient.Unlock()
+	return hzClient.Client
+}
+
+// withClusterView returns a copy of the config, the members are read from the client.
+func withClusterView(config *hazelcast.Config) hazelcast.Config {
+	return config.Clone()
+}
+
+// resetClusterView does nothing, the members are read from the client.
+func resetClusterView() {}
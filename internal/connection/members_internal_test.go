@@ -0,0 +1,42 @@
+//go:build hazelcastinternal
+// +build hazelcastinternal
+
+package connection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMembers_NotStarted(t *testing.T) {
+	defer ResetClient()
+	members, err := Members(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, members)
+	connected, known := MemberConnected(types.NewUUIDWith(0, 1))
+	require.False(t, connected)
+	require.False(t, known)
+}
+
+func TestRESTAddresses(t *testing.T) {
+	var c hazelcast.Config
+	require.Equal(t, []string{"localhost:5701"}, RESTAddresses(&c))
+	c.Cluster.Network.SetAddresses("10.0.0.1", "10.0.0.2:5702")
+	require.Equal(t, []string{"10.0.0.1:5701", "10.0.0.2:5702"}, RESTAddresses(&c))
+}
+
+func TestWithClusterView(t *testing.T) {
+	var c hazelcast.Config
+	c.Cluster.Name = "dev"
+	c.AddMembershipListener(func(cluster.MembershipStateChanged) {})
+	cp := withClusterView(&c)
+	require.Equal(t, "dev", cp.Cluster.Name)
+	// the members are read from the client, so no listener is added
+	require.Equal(t, 1, reflect.ValueOf(cp).FieldByName("membershipListeners").Len())
+}
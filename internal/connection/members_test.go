@@ -0,0 +1,56 @@
+//go:build !hazelcastinternal
+// +build !hazelcastinternal
+
+package connection
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/cluster"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTAddresses(t *testing.T) {
+	defer resetClusterView()
+	var c hazelcast.Config
+	require.Equal(t, []string{"localhost:5701"}, RESTAddresses(&c))
+	c.Cluster.Network.SetAddresses("10.0.0.1", "10.0.0.2:5702")
+	updateClusterView(cluster.MembershipStateChanged{
+		State:  cluster.MembershipStateAdded,
+		Member: cluster.MemberInfo{UUID: types.NewUUIDWith(0, 1), Address: "10.0.0.2:5702"},
+	})
+	updateClusterView(cluster.MembershipStateChanged{
+		State:  cluster.MembershipStateAdded,
+		Member: cluster.MemberInfo{UUID: types.NewUUIDWith(0, 2), Address: "10.0.0.3:5701"},
+	})
+	require.Equal(t, []string{"10.0.0.1:5701", "10.0.0.2:5702", "10.0.0.3:5701"}, RESTAddresses(&c))
+}
+
+func TestCopyWithoutListeners(t *testing.T) {
+	var c hazelcast.Config
+	c.ClientName = "clc"
+	c.Cluster.Name = "dev"
+	c.Cluster.Network.SetAddresses("10.0.0.1:5701")
+	c.SetLabels("a", "b")
+	c.AddMembershipListener(func(cluster.MembershipStateChanged) {})
+	cp := copyWithoutListeners(&c)
+	require.Equal(t, c.ClientName, cp.ClientName)
+	require.Equal(t, c.Cluster, cp.Cluster)
+	require.Equal(t, c.Labels, cp.Labels)
+	// the copy is deep, changing it does not change the original
+	cp.Cluster.Network.Addresses[0] = "10.0.0.2:5701"
+	require.Equal(t, []string{"10.0.0.1:5701"}, c.Cluster.Network.Addresses)
+	// the listener of the cluster view is added to the copy only, so that it does not pile up on the original
+	for i := 0; i < 3; i++ {
+		cp = withClusterView(&c)
+	}
+	require.Equal(t, 1, membershipListenerCount(c))
+	require.Equal(t, 1, membershipListenerCount(cp))
+}
+
+func membershipListenerCount(c hazelcast.Config) int {
+	return reflect.ValueOf(c).FieldByName("membershipListeners").Len()
+}
@@ -25,6 +25,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hazelcast/hazelcast-go-client"
 
+	"github.com/hazelcast/hazelcast-commandline-client/config"
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
 )
 
@@ -33,14 +34,19 @@ const clientResponseTimeoutDeadline = 1 * time.Second
 var hzClient = &struct {
 	*hazelcast.Client
 	sync.Mutex
+	// disconnected is set by Disconnect, the client is not started again until Connect is called
+	disconnected bool
 }{}
 
 func ConnectToCluster(ctx context.Context, clientConfig *hazelcast.Config) (*hazelcast.Client, error) {
 	var err error
 	hzClient.Lock()
 	defer hzClient.Unlock()
+	if hzClient.disconnected {
+		return nil, hzcerrors.NewLoggableError(nil, "Not connected to a cluster, use connect to connect to a cluster")
+	}
 	if hzClient.Client == nil {
-		configCopy := clientConfig.Clone()
+		configCopy := withClusterView(clientConfig)
 		hzClient.Client, err = hazelcast.StartNewClientWithConfig(ctx, configCopy)
 	}
 	return hzClient.Client, err
@@ -55,7 +61,7 @@ func ConnectToClusterInteractive(ctx context.Context, clientConfig *hazelcast.Co
 		escaped := false
 		m := newConnectionSpinnerModel(
 			clientConfig.Cluster.Name,
-			clientConfig.Cluster.Network.Addresses[0],
+			config.GetClusterAddress(clientConfig),
 			"logfile",
 			&escaped,
 		)
@@ -118,9 +124,48 @@ func asyncGetHZClientInstance(ctx context.Context, clientConfig *hazelcast.Confi
 	return clientCh, errCh
 }
 
+// Connect starts the client with the given configuration after Disconnect, displaying the spinner if connecting
+// takes long. The client stays disconnected if it can not connect.
+func Connect(ctx context.Context, clientConfig *hazelcast.Config) (*hazelcast.Client, error) {
+	hzClient.Lock()
+	hzClient.disconnected = false
+	hzClient.Unlock()
+	client, err := ConnectToClusterInteractive(ctx, clientConfig)
+	if err != nil {
+		hzClient.Lock()
+		hzClient.disconnected = true
+		hzClient.Unlock()
+	}
+	return client, err
+}
+
+// Disconnect gracefully shuts down the client, if it is started. The commands can not connect to a cluster until
+// Connect is called.
+func Disconnect(ctx context.Context) error {
+	hzClient.Lock()
+	defer hzClient.Unlock()
+	hzClient.disconnected = true
+	if hzClient.Client == nil {
+		return nil
+	}
+	err := hzClient.Client.Shutdown(ctx)
+	hzClient.Client = nil
+	resetClusterView()
+	return err
+}
+
+// Disconnected returns true if the client is disconnected with Disconnect or could not connect with Connect.
+func Disconnected() bool {
+	hzClient.Lock()
+	defer hzClient.Unlock()
+	return hzClient.disconnected
+}
+
 // ResetClient is for testing
 func ResetClient() {
 	hzClient.Lock()
 	defer hzClient.Unlock()
 	hzClient.Client = nil
+	hzClient.disconnected = false
+	resetClusterView()
 }
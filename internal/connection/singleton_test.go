@@ -0,0 +1,21 @@
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisconnect(t *testing.T) {
+	defer ResetClient()
+	require.False(t, Disconnected())
+	require.NoError(t, Disconnect(context.Background()))
+	require.True(t, Disconnected())
+	var config hazelcast.Config
+	_, err := ConnectToCluster(context.Background(), &config)
+	require.EqualError(t, err, "Not connected to a cluster, use connect to connect to a cluster")
+	ResetClient()
+	require.False(t, Disconnected())
+}
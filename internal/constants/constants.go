@@ -24,14 +24,40 @@ const (
 	ClusterGetStateEndpoint    = "/hazelcast/rest/management/cluster/state"
 	ClusterChangeStateEndpoint = "/hazelcast/rest/management/cluster/changeState"
 	ClusterShutdownEndpoint    = "/hazelcast/rest/management/cluster/clusterShutdown"
+	MemberShutdownEndpoint     = "/hazelcast/rest/management/cluster/memberShutdown"
 	ClusterVersionEndpoint     = "/hazelcast/rest/management/cluster/version"
+	ClusterHealthEndpoint      = "/hazelcast/health"
+	ClusterReadyEndpoint       = "/hazelcast/health/ready"
+	ForceStartEndpoint         = "/hazelcast/rest/management/cluster/forceStart"
+	PartialStartEndpoint       = "/hazelcast/rest/management/cluster/partialStart"
+	BackupEndpoint             = "/hazelcast/rest/management/cluster/hotBackup"
+	BackupInterruptEndpoint    = "/hazelcast/rest/management/cluster/hotBackupInterrupt"
+	LogLevelEndpoint           = "/hazelcast/rest/log-level"
+	LogLevelResetEndpoint      = "/hazelcast/rest/log-level/reset"
+	ClusterLicenseEndpoint     = "/hazelcast/rest/license"
+	ClusterInfoEndpoint        = "/hazelcast/rest/cluster"
 )
 
 const (
 	ClusterGetState    = "get-state"
 	ClusterChangeState = "change-state"
 	ClusterShutdown    = "shutdown"
+	MemberShutdown     = "member-shutdown"
 	ClusterVersion     = "version"
+	ClusterLicense     = "license"
+	ClusterInfo        = "info"
+)
+
+const (
+	PersistenceForceStart      = "force-start"
+	PersistencePartialStart    = "partial-start"
+	PersistenceBackup          = "backup"
+	PersistenceBackupInterrupt = "backup-interrupt"
+)
+
+const (
+	LogLevelSet   = "log-level-set"
+	LogLevelReset = "log-level-reset"
 )
 
 const (
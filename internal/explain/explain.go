@@ -0,0 +1,284 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package explain parses the output of the SQL EXPLAIN statement and renders it as a tree.
+//
+// The member returns one row per relational operator, children are indented by two spaces:
+//
+//	HashJoinPhysicalRel(condition=[=($0, $2)], joinType=[inner])
+//	  FullScanPhysicalRel(table=[[hazelcast, public, a[projects=[$0, $1]]]], discriminator=[0])
+//	  IndexScanMapPhysicalRel(table=[[hazelcast, public, b[projects=[$0, $1]]]], index=[b_idx], ...)
+package explain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/hazelcast/hazelcast-go-client"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
+)
+
+const indentWidth = 2
+
+// attributes which hold filter expressions
+var filterAttributes = map[string]struct{}{
+	"filter":       {},
+	"condition":    {},
+	"$condition":   {},
+	"indexExp":     {},
+	"remainderExp": {},
+}
+
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// Node is a relational operator in the plan.
+type Node struct {
+	Operator string
+	// Table is the name of the scanned object, if any
+	Table string
+	// Index is the name of the used index, if any
+	Index string
+	// Filters are the filter expressions applied by the operator
+	Filters    []string
+	Attributes []Attribute
+	Children   []*Node
+}
+
+// IsFullScan reports whether the operator scans all entries of an object.
+func (n *Node) IsFullScan() bool {
+	return strings.Contains(n.Operator, "FullScan")
+}
+
+// Plan runs EXPLAIN for the query and returns its operator tree.
+func Plan(ctx context.Context, c *hazelcast.Client, query string) (*Node, error) {
+	result, err := c.SQL().Execute(ctx, "EXPLAIN "+query)
+	if err != nil {
+		return nil, fmt.Errorf("querying: %w", err)
+	}
+	defer result.Close()
+	it, err := result.Iterator()
+	if err != nil {
+		return nil, fmt.Errorf("initializing result iterator: %w", err)
+	}
+	var rows []string
+	for it.HasNext() {
+		row, err := it.Next()
+		if err != nil {
+			return nil, fmt.Errorf("fetching row: %w", err)
+		}
+		v, err := row.Get(0)
+		if err != nil {
+			return nil, fmt.Errorf("reading row: %w", err)
+		}
+		rows = append(rows, fmt.Sprint(v))
+	}
+	return Parse(rows)
+}
+
+// Parse builds the operator tree from the rows of the EXPLAIN result.
+func Parse(rows []string) (*Node, error) {
+	var root *Node
+	// stack of the last node seen at each level
+	var stack []*Node
+	for i, row := range rows {
+		if strings.TrimSpace(row) == "" {
+			continue
+		}
+		trimmed := strings.TrimLeft(row, " ")
+		level := (len(row) - len(trimmed)) / indentWidth
+		n, err := parseNode(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("parsing row %d: %w", i+1, err)
+		}
+		if root == nil {
+			if level != 0 {
+				return nil, fmt.Errorf("parsing row %d: plan must start with the root operator", i+1)
+			}
+			root = n
+			stack = []*Node{n}
+			continue
+		}
+		if level == 0 || level > len(stack) {
+			return nil, fmt.Errorf("parsing row %d: unexpected indentation", i+1)
+		}
+		parent := stack[level-1]
+		parent.Children = append(parent.Children, n)
+		stack = append(stack[:level], n)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("plan is empty")
+	}
+	return root, nil
+}
+
+func parseNode(s string) (*Node, error) {
+	open := strings.IndexByte(s, '(')
+	if open < 0 {
+		return &Node{Operator: s}, nil
+	}
+	if !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("unbalanced parentheses in %s", s)
+	}
+	n := &Node{Operator: s[:open]}
+	for _, part := range splitTopLevel(s[open+1 : len(s)-1]) {
+		name, value := splitAttribute(part)
+		value = unwrap(value)
+		n.Attributes = append(n.Attributes, Attribute{Name: name, Value: value})
+		switch name {
+		case "table":
+			n.Table, n.Filters = parseTable(value, n.Filters)
+		case "index":
+			n.Index = value
+		}
+		if _, ok := filterAttributes[name]; ok && value != "null" {
+			n.Filters = append(n.Filters, value)
+		}
+	}
+	return n, nil
+}
+
+// parseTable returns the object name and the pushed down filter from a table attribute such as:
+// [hazelcast, public, employees[projects=[$0, $1], filter=>($1, 30)]]
+func parseTable(value string, filters []string) (string, []string) {
+	parts := splitTopLevel(unwrap(value))
+	last := parts[len(parts)-1]
+	open := strings.IndexByte(last, '[')
+	if open < 0 {
+		return last, filters
+	}
+	for _, p := range splitTopLevel(last[open+1 : len(last)-1]) {
+		if name, v := splitAttribute(p); name == "filter" {
+			filters = append(filters, v)
+		}
+	}
+	return last[:open], filters
+}
+
+func splitAttribute(s string) (string, string) {
+	i := strings.IndexByte(s, '=')
+	if i < 0 {
+		return "", s
+	}
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+}
+
+// unwrap removes a single pair of enclosing square brackets.
+func unwrap(s string) string {
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// splitTopLevel splits s at the commas which are not enclosed by any brackets.
+func splitTopLevel(s string) []string {
+	var parts []string
+	var depth, start int
+	for i, r := range s {
+		switch r {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if rest := strings.TrimSpace(s[start:]); rest != "" {
+		parts = append(parts, rest)
+	}
+	return parts
+}
+
+type styles struct {
+	operator, fullScan, label, highlight, faint lipgloss.Style
+	// fullScanMarker must stand out without colors too
+	fullScanMarker string
+}
+
+func newStyles(colored bool) styles {
+	if !colored {
+		plain := lipgloss.NewStyle()
+		return styles{plain, plain, plain, plain, plain, "[FULL SCAN]"}
+	}
+	return styles{
+		operator:  lipgloss.NewStyle().Bold(true),
+		fullScan:  lipgloss.NewStyle().Bold(true).Reverse(true),
+		label:     lipgloss.NewStyle().Foreground(tuiutil.FooterForeground()),
+		highlight: lipgloss.NewStyle().Foreground(tuiutil.Highlight()).Bold(true),
+		faint:     lipgloss.NewStyle().Faint(true),
+		// reversed colors with padding look like a badge
+		fullScanMarker: " FULL SCAN ",
+	}
+}
+
+// Render renders the plan as an indented tree, highlighting full scans, scanned objects, indexes and filters.
+func Render(root *Node, colored bool) string {
+	var b strings.Builder
+	renderNode(&b, root, "", "", newStyles(colored))
+	return b.String()
+}
+
+func renderNode(b *strings.Builder, n *Node, prefix, childPrefix string, st styles) {
+	b.WriteString(prefix)
+	b.WriteString(st.operator.Render(n.Operator))
+	if n.IsFullScan() {
+		b.WriteString(" ")
+		b.WriteString(st.fullScan.Render(st.fullScanMarker))
+	}
+	b.WriteString("\n")
+	attrPrefix := childPrefix + "   "
+	if len(n.Children) > 0 {
+		attrPrefix = childPrefix + "│  "
+	}
+	line := func(label, value string, style lipgloss.Style) {
+		b.WriteString(fmt.Sprintf("%s%s %s\n", attrPrefix, st.label.Render(label+":"), style.Render(value)))
+	}
+	if n.Table != "" {
+		line("table", n.Table, st.highlight)
+	}
+	if n.Index != "" {
+		line("index", n.Index, st.highlight)
+	}
+	for _, f := range n.Filters {
+		line("filter", f, st.highlight)
+	}
+	for _, a := range n.Attributes {
+		if a.Name == "table" || a.Name == "index" {
+			continue
+		}
+		if _, ok := filterAttributes[a.Name]; ok {
+			continue
+		}
+		line(a.Name, a.Value, st.faint)
+	}
+	for i, c := range n.Children {
+		if i == len(n.Children)-1 {
+			renderNode(b, c, childPrefix+"└─ ", childPrefix+"   ", st)
+			continue
+		}
+		renderNode(b, c, childPrefix+"├─ ", childPrefix+"│  ", st)
+	}
+}
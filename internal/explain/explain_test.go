@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package explain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var joinPlan = []string{
+	"HashJoinPhysicalRel(condition=[=($0, $2)], joinType=[inner])",
+	"  FullScanPhysicalRel(table=[[hazelcast, public, a[projects=[$0, $1], filter=>($1, 30)]]], discriminator=[0])",
+	"  IndexScanMapPhysicalRel(table=[[hazelcast, public, b[projects=[$0, $1]]]], index=[b_idx], indexExp=[=($0, 1)], remainderExp=[null])",
+}
+
+func TestParse(t *testing.T) {
+	root, err := Parse(joinPlan)
+	require.NoError(t, err)
+	require.Equal(t, "HashJoinPhysicalRel", root.Operator)
+	require.Equal(t, []string{"=($0, $2)"}, root.Filters)
+	require.Len(t, root.Children, 2)
+	scan := root.Children[0]
+	require.True(t, scan.IsFullScan())
+	require.Equal(t, "a", scan.Table)
+	require.Equal(t, []string{">($1, 30)"}, scan.Filters)
+	idx := root.Children[1]
+	require.False(t, idx.IsFullScan())
+	require.Equal(t, "b", idx.Table)
+	require.Equal(t, "b_idx", idx.Index)
+	require.Equal(t, []string{"=($0, 1)"}, idx.Filters)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse(nil)
+	require.Error(t, err)
+	_, err = Parse([]string{"  FullScanPhysicalRel(table=[[hazelcast, public, a]])"})
+	require.Error(t, err)
+	_, err = Parse([]string{"ProjectPhysicalRel(a=[$0])", "      FullScanPhysicalRel(table=[[hazelcast, public, a]])"})
+	require.Error(t, err)
+	_, err = Parse([]string{"ProjectPhysicalRel(a=[$0]"})
+	require.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	root, err := Parse(joinPlan)
+	require.NoError(t, err)
+	require.Equal(t, `HashJoinPhysicalRel
+│  filter: =($0, $2)
+│  joinType: inner
+├─ FullScanPhysicalRel [FULL SCAN]
+│     table: a
+│     filter: >($1, 30)
+│     discriminator: 0
+└─ IndexScanMapPhysicalRel
+      table: b
+      index: b_idx
+      filter: =($0, 1)
+`, Render(root, false))
+}
@@ -95,7 +95,7 @@ func TestCLCInteractiveModeWithCluster(t *testing.T, cluster *TestCluster, args
 	defer func() {
 		cobraprompt.OptionsHookForTests = nil
 	}()
-	prompt, err := runner.RunCmdInteractively(ctx, &cfg, l, rootCmd, globalFlagValues.NoColor)
+	prompt, err := runner.RunCmdInteractively(ctx, &cfg, l, rootCmd, globalFlagValues)
 	require.NoError(t, err)
 	done := make(chan error, 1)
 	go func() {
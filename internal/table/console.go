@@ -3,109 +3,290 @@ package table
 import (
 	"fmt"
 	"io"
-	"strconv"
+	"math"
+	"os"
+	"sort"
 	"strings"
 
 	"github.com/mattn/go-runewidth"
+	"github.com/muesli/reflow/wordwrap"
+	"github.com/muesli/reflow/wrap"
 	console "github.com/nathan-fiscaletti/consolesize-go"
+	"golang.org/x/term"
 )
 
 var ConsoleSize = console.GetConsoleSize
 
+// IsTerminal reports whether out is a terminal. Values are not truncated if out is not a terminal.
+var IsTerminal = func(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
 const (
 	alignLeft = iota
 	alignCenter
+	alignRight
 )
 
+// minColumnWidth is the narrowest a column gets when the values do not fit the console, enough for "...".
+const minColumnWidth = 3
+
+// TabularWriter writes the rows as a table. The widths of the columns are computed from the header and a sample of
+// the first rows, which are held back until the sample is complete or Flush is called. The values of the following
+// rows are truncated or wrapped to the same widths.
 type TabularWriter struct {
-	out         io.Writer
-	rowsWritten int
-	rowCount    int
-	width       int
+	out io.Writer
+	// Wrap wraps the long values in their columns instead of truncating them.
+	Wrap       bool
+	header     []string
+	alignments []int
+	// pending are the rows held back to compute the widths
+	pending    [][]string
+	sampleSize int
+	// widths are the widths of the values in the columns, nil until computed
+	widths   []int
+	truncate bool
 }
 
+// NewTableWriter returns a writer which computes the column widths from the rows of the first console page.
 func NewTableWriter(out io.Writer) *TabularWriter {
 	return &TabularWriter{out: out}
 }
 
+// NewStreamingTableWriter returns a writer which computes the column widths from the first row only, so that
+// the rows of a never ending result are written as soon as they arrive. The widths are kept for all rows,
+// so that the rows stay aligned.
+func NewStreamingTableWriter(out io.Writer) *TabularWriter {
+	return &TabularWriter{out: out, sampleSize: 1}
+}
+
+// NewBufferedTableWriter returns a writer which computes the column widths from all rows, which are held back until
+// Flush is called. It suits the tables with a known, small number of rows.
+func NewBufferedTableWriter(out io.Writer) *TabularWriter {
+	return &TabularWriter{out: out, sampleSize: math.MaxInt}
+}
+
+// AlignRight aligns the values of the columns at the given indexes to the right, e.g. numbers.
+func (t *TabularWriter) AlignRight(columns ...int) {
+	for _, c := range columns {
+		for len(t.alignments) <= c {
+			t.alignments = append(t.alignments, alignLeft)
+		}
+		t.alignments[c] = alignRight
+	}
+}
+
+/*
+WriteHeader sets the header of the table, which is written with the first rows in the form:
++----------------------------+
+| vegetables | fruit  | rank |
++----------------------------+
+*/
+func (t *TabularWriter) WriteHeader(cells ...interface{}) error {
+	t.header = cellTexts(cells)
+	return nil
+}
+
 func (t *TabularWriter) Write(cells ...interface{}) error {
-	t.initSize(cells)
-	colWidth := (t.width - len(cells) - 1) / len(cells)
-	line := makeTabularLine(colWidth, alignLeft, cells...)
-	if _, err := fmt.Fprintln(t.out, line); err != nil {
-		return err
+	row := cellTexts(cells)
+	if t.widths != nil {
+		return t.writeRow(row)
+	}
+	t.pending = append(t.pending, row)
+	if t.sampleSize == 0 {
+		_, rows := ConsoleSize()
+		t.sampleSize = max(rows, 4)
+	}
+	if len(t.pending) < t.sampleSize {
+		return nil
+	}
+	return t.Flush()
+}
+
+// Flush writes the rows held back to compute the column widths, and the header if it is not written yet.
+// It must be called after the last row.
+func (t *TabularWriter) Flush() error {
+	if t.widths == nil {
+		if t.header == nil && len(t.pending) == 0 {
+			return nil
+		}
+		t.measure()
+		if err := t.writeHeader(); err != nil {
+			return err
+		}
 	}
-	t.rowsWritten++
-	if t.rowsWritten == t.rowCount {
-		// reset state for a new page
-		t.rowsWritten = 0
+	for _, row := range t.pending {
+		if err := t.writeRow(row); err != nil {
+			return err
+		}
 	}
+	t.pending = nil
 	return nil
 }
 
-func (t *TabularWriter) initSize(cells []interface{}) {
-	if t.rowsWritten != 0 {
-		// not start state
-		return
+// measure computes the column widths from the header and the pending rows. If out is a terminal, the columns are
+// narrowed to fit the console, starting from the widest ones.
+func (t *TabularWriter) measure() {
+	n := len(t.header)
+	for _, row := range t.pending {
+		n = max(n, len(row))
+	}
+	natural := make([]int, n)
+	for i := range natural {
+		natural[i] = 1
 	}
-	// start state
-	t.width, t.rowCount = ConsoleSize()
-	// minimum space required for => | ... | ... | ... |
-	minWidth := (len(cells)+1)*2 + len(cells)*3
-	if t.width < minWidth {
-		t.width = minWidth
+	for _, row := range append([][]string{t.header}, t.pending...) {
+		for i, c := range row {
+			if !t.Wrap {
+				c = strings.ReplaceAll(c, "\n", " ")
+			}
+			natural[i] = max(natural[i], textWidth(c))
+		}
 	}
-	if t.rowCount < 4 {
-		t.rowCount = 4
+	t.truncate = IsTerminal(t.out)
+	if !t.truncate {
+		t.widths = natural
+		return
 	}
+	width, _ := ConsoleSize()
+	// each column takes "| " and " ", the line ends with "|" and must not reach the last column of the console
+	t.widths = fitWidths(natural, width-3*n-2)
 }
 
-/*
-WriteHeader outputs header for the table with the form:
-+--------------------------------------+
-| vegetables | fruit      | rank       |
-+--------------------------------------+
-*/
-const cornersWithPlusSign = 2
+// fitWidths narrows the widest columns until the total width is at most budget. Columns narrower than their
+// share of the budget keep their widths.
+func fitWidths(natural []int, budget int) []int {
+	widths := append([]int{}, natural...)
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	if total <= budget {
+		return widths
+	}
+	order := make([]int, len(widths))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return widths[order[a]] < widths[order[b]]
+	})
+	remaining := budget
+	for i, c := range order {
+		share := remaining / (len(order) - i)
+		if widths[c] > share {
+			widths[c] = max(share, minColumnWidth)
+		}
+		remaining -= widths[c]
+	}
+	return widths
+}
 
-func (t *TabularWriter) WriteHeader(cells ...interface{}) error {
-	t.initSize(cells)
-	var (
-		sepCnt       = len(cells) + 1
-		totalWidth   = t.width
-		numOfColumns = len(cells)
-	)
-	colWidth := (totalWidth - sepCnt) / numOfColumns
-	effectiveLineWidth := colWidth*len(cells) + sepCnt - cornersWithPlusSign
-	headerBorder := fmt.Sprintf("+%s+\n", strings.Repeat("-", effectiveLineWidth))
-	// write upper border
-	if _, err := fmt.Fprintf(t.out, headerBorder); err != nil {
+func (t *TabularWriter) writeHeader() error {
+	if t.header == nil {
+		return nil
+	}
+	lineWidth := 1
+	for _, w := range t.widths {
+		lineWidth += w + 3
+	}
+	border := fmt.Sprintf("+%s+\n", strings.Repeat("-", lineWidth-2))
+	if _, err := io.WriteString(t.out, border); err != nil {
 		return err
 	}
-	// write column names
-	line := makeTabularLine(colWidth, alignCenter, cells...)
-	if _, err := fmt.Fprintln(t.out, line); err != nil {
+	if err := t.writeLines(t.header, func(int) int { return alignCenter }); err != nil {
 		return err
 	}
-	// write lower border
-	_, err := fmt.Fprintf(t.out, headerBorder)
+	_, err := io.WriteString(t.out, border)
 	return err
 }
 
-const paddingFromSeparators = 2
+func (t *TabularWriter) writeRow(row []string) error {
+	return t.writeLines(row, func(i int) int {
+		if i < len(t.alignments) {
+			return t.alignments[i]
+		}
+		return alignLeft
+	})
+}
 
-func makeTabularLine(cellWidth, alignment int, cells ...interface{}) string {
+// writeLines writes the cells as a line, or as many lines if the values are wrapped.
+func (t *TabularWriter) writeLines(cells []string, alignment func(i int) int) error {
+	columns := make([][]string, len(t.widths))
+	height := 1
+	for i, w := range t.widths {
+		var c string
+		if i < len(cells) {
+			c = cells[i]
+		}
+		columns[i] = t.fit(c, w)
+		height = max(height, len(columns[i]))
+	}
 	var b strings.Builder
-	for _, c := range cells {
-		s := fmt.Sprint(c)
-		td := fmt.Sprintf(" %s ", runewidth.Truncate(s, cellWidth-paddingFromSeparators, "..."))
-		switch alignment {
-		case alignLeft:
-			b.WriteString(fmt.Sprintf("|%-"+strconv.Itoa(cellWidth)+"s", td))
-		case alignCenter:
-			b.WriteString(fmt.Sprintf("|%"+strconv.Itoa(cellWidth)+"s", td+strings.Repeat(" ", (cellWidth-len(td))/2)))
+	for l := 0; l < height; l++ {
+		for i, w := range t.widths {
+			var s string
+			if l < len(columns[i]) {
+				s = columns[i][l]
+			}
+			b.WriteString("| ")
+			b.WriteString(pad(s, w, alignment(i)))
+			b.WriteString(" ")
 		}
+		b.WriteString("|\n")
+	}
+	_, err := io.WriteString(t.out, b.String())
+	return err
+}
+
+// fit returns the lines of the value in a column of the given width.
+func (t *TabularWriter) fit(s string, width int) []string {
+	if t.Wrap {
+		return strings.Split(wrap.String(wordwrap.String(s, width), width), "\n")
+	}
+	s = strings.ReplaceAll(s, "\n", " ")
+	if t.truncate {
+		s = runewidth.Truncate(s, width, "...")
+	}
+	return []string{s}
+}
+
+func pad(s string, width, alignment int) string {
+	space := width - textWidth(s)
+	if space <= 0 {
+		return s
+	}
+	switch alignment {
+	case alignRight:
+		return strings.Repeat(" ", space) + s
+	case alignCenter:
+		left := space / 2
+		return strings.Repeat(" ", left) + s + strings.Repeat(" ", space-left)
+	}
+	return s + strings.Repeat(" ", space)
+}
+
+// textWidth is the width of the widest line of s.
+func textWidth(s string) int {
+	w := 0
+	for _, l := range strings.Split(s, "\n") {
+		w = max(w, runewidth.StringWidth(l))
+	}
+	return w
+}
+
+func cellTexts(cells []interface{}) []string {
+	texts := make([]string, len(cells))
+	for i, c := range cells {
+		texts[i] = fmt.Sprint(c)
+	}
+	return texts
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
 	}
-	b.WriteString("|")
-	return b.String()
+	return b
 }
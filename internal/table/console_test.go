@@ -1,74 +1,164 @@
 package table
 
 import (
-	"bufio"
 	"bytes"
+	"io"
 	"strings"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// fakeTerminal makes the writers treat the output as a terminal of the given size.
+func fakeTerminal(t *testing.T, width, height int) {
+	consoleSize, isTerminal := ConsoleSize, IsTerminal
+	t.Cleanup(func() {
+		ConsoleSize, IsTerminal = consoleSize, isTerminal
+	})
+	ConsoleSize = func() (int, int) {
+		return width, height
+	}
+	IsTerminal = func(io.Writer) bool {
+		return true
+	}
+}
+
+func lines(b *bytes.Buffer) []string {
+	return strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+}
+
 func TestTabularWriter_Write(t *testing.T) {
+	fakeTerminal(t, 50, 10)
 	buffer := bytes.NewBuffer(nil)
-	reader, writer := bufio.NewReader(buffer), buffer
-	w := NewTableWriter(writer)
-	steps := []struct {
-		desc               string
-		cells              []interface{}
-		changeTerminalSize func()
-		expectedLine       string
-		expectedErr        error
-	}{
-		{
-			desc: "set initial console size",
-			changeTerminalSize: func() {
-				ConsoleSize = func() (int, int) {
-					return 50, 3
-				}
-			},
-		},
-		{
-			desc:         "first line",
-			cells:        []interface{}{"someValue", 5.0, time.Date(1994, 8, 30, 0, 0, 0, 0, time.UTC)},
-			expectedLine: "| someValue     | 5             | 1994-08-30... |",
-		},
-	}
-	for _, s := range steps {
-		if s.changeTerminalSize != nil {
-			// change terminal size and continue
-			s.changeTerminalSize()
-			continue
-		}
-		err := w.Write(s.cells...)
-		assert.Equal(t, s.expectedErr, err)
-		line, err := reader.ReadString('\n')
-		if err != nil {
-			t.Fatal(err)
-		}
-		assert.Equal(t, s.expectedLine+"\n", line)
+	w := NewTableWriter(buffer)
+	w.AlignRight(0)
+	assert.NoError(t, w.WriteHeader("id", "description"))
+	assert.NoError(t, w.Write(1, "short"))
+	assert.NoError(t, w.Write(100, "a description which does not fit the console width"))
+	// the rows are held back until the sample is complete
+	assert.Empty(t, buffer.String())
+	assert.NoError(t, w.Flush())
+	expected := []string{
+		"+-----------------------------------------------+",
+		"| id  |               description               |",
+		"+-----------------------------------------------+",
+		"|   1 | short                                   |",
+		"| 100 | a description which does not fit the... |",
 	}
+	assert.Equal(t, expected, lines(buffer))
+	// the following rows are written at once, with the same widths
+	buffer.Reset()
+	assert.NoError(t, w.Write(2, "x"))
+	assert.Equal(t, "|   2 | x                                       |\n", buffer.String())
 }
 
 func TestTabularWriter_WriteHeader(t *testing.T) {
-	ConsoleSize = func() (int, int) {
-		return 50, 3
-	}
+	fakeTerminal(t, 50, 3)
 	buffer := bytes.NewBuffer(nil)
 	w := NewTableWriter(buffer)
 	if err := w.WriteHeader("vegetables", "fruit", "rank"); err != nil {
 		t.Fatal(err)
 	}
-	expected := "+-----------------------------------------------+\n" +
-		"|   vegetables  |     fruit     |      rank     |\n" +
-		"+-----------------------------------------------+\n"
-	out := buffer.String()
-	assert.Equal(t, expected, out)
-	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
-	for _, l := range lines {
-		expectedWidth, _ := ConsoleSize()
-		// -1 for the newline
-		assert.Equal(t, expectedWidth-1, len(l))
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
 	}
+	expected := "+---------------------------+\n" +
+		"| vegetables | fruit | rank |\n" +
+		"+---------------------------+\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestTabularWriter_FitsConsole(t *testing.T) {
+	fakeTerminal(t, 40, 10)
+	buffer := bytes.NewBuffer(nil)
+	w := NewTableWriter(buffer)
+	assert.NoError(t, w.WriteHeader("a", "b", "c"))
+	assert.NoError(t, w.Write(strings.Repeat("x", 30), "y", strings.Repeat("z", 30)))
+	assert.NoError(t, w.Flush())
+	for _, l := range lines(buffer) {
+		assert.Len(t, l, 39)
+	}
+	assert.Equal(t, "| xxxxxxxxxxx... | y | zzzzzzzzzzz... |", lines(buffer)[3])
+}
+
+func TestTabularWriter_Wrap(t *testing.T) {
+	fakeTerminal(t, 30, 10)
+	buffer := bytes.NewBuffer(nil)
+	w := NewTableWriter(buffer)
+	w.Wrap = true
+	assert.NoError(t, w.WriteHeader("id", "text"))
+	assert.NoError(t, w.Write(1, "the quick brown fox jumps over the lazy dog"))
+	assert.NoError(t, w.Flush())
+	expected := []string{
+		"| 1  | the quick brown fox  |",
+		"|    | jumps over the lazy  |",
+		"|    | dog                  |",
+	}
+	assert.Equal(t, expected, lines(buffer)[3:])
+}
+
+func TestTabularWriter_NotTerminal(t *testing.T) {
+	consoleSize := ConsoleSize
+	defer func() {
+		ConsoleSize = consoleSize
+	}()
+	ConsoleSize = func() (int, int) {
+		return 20, 10
+	}
+	buffer := bytes.NewBuffer(nil)
+	w := NewTableWriter(buffer)
+	assert.NoError(t, w.WriteHeader("id", "text"))
+	assert.NoError(t, w.Write(1, "a value wider than the console"))
+	assert.NoError(t, w.Flush())
+	assert.NoError(t, w.Write(2, "a longer value which is not truncated either"))
+	l := lines(buffer)
+	assert.Equal(t, "| 1  | a value wider than the console |", l[3])
+	assert.Equal(t, "| 2  | a longer value which is not truncated either |", l[4])
+}
+
+func TestStreamingTabularWriter_KeepsWidth(t *testing.T) {
+	fakeTerminal(t, 50, 3)
+	buffer := bytes.NewBuffer(nil)
+	w := NewStreamingTableWriter(buffer)
+	for i := 0; i < 10; i++ {
+		if i == 5 {
+			// resizing the console must not change the width of the following rows
+			ConsoleSize = func() (int, int) {
+				return 80, 3
+			}
+		}
+		if err := w.Write("someValue", i); err != nil {
+			t.Fatal(err)
+		}
+		// each row is written as soon as it arrives
+		assert.Len(t, lines(buffer), i+1)
+	}
+	l := lines(buffer)
+	assert.Equal(t, "| someValue | 0 |", l[0])
+	for _, line := range l {
+		assert.Equal(t, len(l[0]), len(line))
+	}
+}
+
+func TestBufferedTabularWriter_MeasuresAllRows(t *testing.T) {
+	fakeTerminal(t, 80, 3)
+	buffer := bytes.NewBuffer(nil)
+	w := NewBufferedTableWriter(buffer)
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, w.Write(strings.Repeat("x", i+1)))
+	}
+	// all rows are held back until Flush
+	assert.Empty(t, buffer.String())
+	assert.NoError(t, w.Flush())
+	l := lines(buffer)
+	assert.Equal(t, "| x     |", l[0])
+	assert.Equal(t, "| xxxxx |", l[4])
+}
+
+func TestFitWidths(t *testing.T) {
+	assert.Equal(t, []int{3, 5}, fitWidths([]int{3, 5}, 10))
+	// the narrow columns keep their widths, the wide ones share the rest
+	assert.Equal(t, []int{2, 9, 9}, fitWidths([]int{2, 30, 40}, 20))
+	// the columns do not get narrower than "..."
+	assert.Equal(t, []int{3, 3}, fitWidths([]int{10, 10}, 2))
 }
@@ -34,13 +34,15 @@ type UIState struct {
 	SQLEdit           bool
 	ExpandColumn      int
 	CurrentTable      int
+	PinnedColumns     int // number of leading columns kept in view when scrolling horizontally
 }
 
 type UIData struct {
 	TableHeaders      map[string][]string // keeps track of which schema has which headers
 	TableHeadersSlice []string
 	TableSlices       map[string][]interface{}
-	TableIndexMap     map[int]string // keeps the schemas in order
+	TableIndexMap     map[int]string    // keeps the schemas in order
+	ColumnMarkers     map[string]string // shown after the column names, such as the sort order
 	EditTextBuffer    string
 }
 
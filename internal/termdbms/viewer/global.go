@@ -66,17 +66,11 @@ func init() {
 		return nil
 	}
 	GlobalCommands["right"] = func(m *TuiModel) tea.Cmd {
-		headers := m.GetHeaders()
-		headersLen := len(headers)
-		if headersLen > maxHeaders && m.Scroll.ScrollXOffset <= headersLen-maxHeaders {
-			m.Scroll.ScrollXOffset++
-		}
+		ScrollRight(m)
 		return nil
 	}
 	GlobalCommands["left"] = func(m *TuiModel) tea.Cmd {
-		if m.Scroll.ScrollXOffset > 0 {
-			m.Scroll.ScrollXOffset--
-		}
+		ScrollLeft(m)
 		return nil
 	}
 	GlobalCommands["s"] = func(m *TuiModel) tea.Cmd {
@@ -149,3 +143,44 @@ func init() {
 		return nil
 	}
 }
+
+// ScrollRight scrolls the columns by one, if there are columns out of view on the right
+func ScrollRight(m *TuiModel) {
+	headers := m.GetHeaders()
+	headersLen := len(headers)
+	if headersLen > maxHeaders && m.Scroll.ScrollXOffset <= headersLen-maxHeaders {
+		m.Scroll.ScrollXOffset++
+	}
+}
+
+// ScrollLeft scrolls the columns back by one
+func ScrollLeft(m *TuiModel) {
+	if m.Scroll.ScrollXOffset > 0 {
+		m.Scroll.ScrollXOffset--
+	}
+}
+
+// SelectNextColumn moves the cursor to the next column, scrolling when the cursor is on the last column in view
+func SelectNextColumn(m *TuiModel) {
+	cw := m.CellWidth()
+	col := m.MouseData.X / cw
+	if col < len(m.Data().TableHeadersSlice)-1 {
+		m.MouseData.X = (col + 1) * cw
+		return
+	}
+	ScrollRight(m)
+}
+
+// SelectPreviousColumn moves the cursor to the previous column, scrolling when the cursor is on the first
+// column after the pinned ones
+func SelectPreviousColumn(m *TuiModel) {
+	cw := m.CellWidth()
+	col := m.MouseData.X / cw
+	if col == m.UI.PinnedColumns && m.Scroll.ScrollXOffset > 0 {
+		ScrollLeft(m)
+		return
+	}
+	if col > 0 {
+		m.MouseData.X = (col - 1) * cw
+	}
+}
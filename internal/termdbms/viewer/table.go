@@ -32,9 +32,14 @@ func init() {
 		bs := m.GetBaseStyle()
 		style := bs.UnsetForeground().UnsetFaint().Underline(true).Bold(true)
 		headers := m.Data().TableHeadersSlice
-		for _, d := range headers {
+		selected := m.MouseData.X / m.CellWidth()
+		for i, d := range headers {
 			// write all headers
-			text := " " + TruncateIfApplicable(m, d)
+			text := " " + TruncateIfApplicable(m, d+m.Data().ColumnMarkers[d])
+			if i == selected {
+				builder = append(builder, style.Copy().Reverse(true).Render(text))
+				continue
+			}
 			builder = append(builder, style.
 				Render(text))
 		}
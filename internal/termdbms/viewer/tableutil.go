@@ -133,7 +133,7 @@ func (m *TuiModel) SetViewSlices() {
 		headersLen := len(headers)
 
 		if headersLen > maxHeaders {
-			headers = headers[m.Scroll.ScrollXOffset : maxHeaders+m.Scroll.ScrollXOffset-1]
+			headers = ScrolledHeaders(headers, maxHeaders-1, m.UI.PinnedColumns, m.Scroll.ScrollXOffset)
 		}
 		// data slices
 		defer func() {
@@ -156,6 +156,17 @@ func (m *TuiModel) SetViewSlices() {
 	}
 }
 
+// ScrolledHeaders returns count headers, the first pinned ones followed by the ones scrolled into view by offset.
+func ScrolledHeaders(headers []string, count, pinned, offset int) []string {
+	count = Min(count, len(headers))
+	pinned = Max(Min(pinned, count-1), 0)
+	scrollable := headers[pinned:]
+	window := count - pinned
+	start := Max(Min(offset, len(scrollable)-window), 0)
+	visible := append([]string{}, headers[:pinned]...)
+	return append(visible, scrollable[start:start+window]...)
+}
+
 // GetSchemaData is a helper function to get the data of the current schema
 func (m *TuiModel) GetSchemaData() map[string][]interface{} {
 	n := m.GetSchemaName()
@@ -177,6 +188,15 @@ func (m *TuiModel) GetSelectedColumnName() string {
 	return headers[index]
 }
 
+// GetVisibleColumnName returns the name of the column under the cursor, taking scrolling and pinned columns into account.
+func (m *TuiModel) GetVisibleColumnName() string {
+	headers := m.Data().TableHeadersSlice
+	if len(headers) == 0 {
+		return ""
+	}
+	return headers[Min(m.MouseData.X/m.CellWidth(), len(headers)-1)]
+}
+
 func (m *TuiModel) GetColumnData() []interface{} {
 	schemaData := m.GetSchemaData()
 	if schemaData == nil {
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package jobcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
+)
+
+const (
+	JobNameFlagShort = "n"
+	JobNameFlag      = "name"
+	SnapshotFlag     = "snapshot"
+)
+
+const JobExample = `  # List the jobs in the cluster.
+  hzc job list
+  # Suspend and then resume a job.
+  hzc job suspend --name my-job
+  hzc job resume --name my-job
+  # Export a snapshot of a job and cancel it, the job can be started again from the snapshot later.
+  hzc job cancel --name my-job --snapshot my-snapshot`
+
+func New(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                "job {list | cancel | suspend | resume | restart | export-snapshot} [--name jobname]",
+		Short:              "Jet job operations",
+		Long:               `Manage the Jet jobs in the cluster, such as the streaming jobs created with the SQL "CREATE JOB" statement`,
+		Example:            JobExample,
+		DisableFlagParsing: true,
+		RunE:               hzcerrors.RootRunnerFnc,
+	}
+	cmd.AddCommand(
+		NewList(config),
+		NewCancel(config),
+		NewAlter(config, "suspend", "Suspend the job, it can be resumed later"),
+		NewAlter(config, "resume", "Resume a suspended job"),
+		NewAlter(config, "restart", "Restart the job, it continues from the last snapshot if there is one"),
+		NewExportSnapshot(config),
+	)
+	return cmd
+}
+
+func NewList(config *hazelcast.Config) *cobra.Command {
+	var outputType string
+	cmd := &cobra.Command{
+		Use:   "list [--output-type type]",
+		Short: "List the names of the active jobs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := sqlcmd.ValidateOutputType(outputType); err != nil {
+				return err
+			}
+			ctx := cmd.Context()
+			c, err := getClient(ctx, config)
+			if err != nil {
+				return err
+			}
+			if err := sqlcmd.Query(ctx, c, "SHOW JOBS", cmd.OutOrStdout(), outputType, false); err != nil && !sqlcmd.IsContextCancellationErr(err) {
+				return translateError(err, config, "Cannot list the jobs")
+			}
+			return nil
+		},
+	}
+	sqlcmd.DecorateCommandWithOutputFlag(&outputType, cmd)
+	return cmd
+}
+
+func NewCancel(config *hazelcast.Config) *cobra.Command {
+	var jobName, snapshotName string
+	cmd := &cobra.Command{
+		Use:     "cancel --name jobname [--snapshot snapshotname]",
+		Short:   "Cancel the job, optionally exporting a snapshot before",
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stmt := fmt.Sprintf("DROP JOB %s", sqlcmd.QuoteIdentifier(jobName))
+			if snapshotName != "" {
+				stmt = fmt.Sprintf("%s WITH SNAPSHOT %s", stmt, sqlcmd.QuoteIdentifier(snapshotName))
+			}
+			if err := executeStatement(cmd.Context(), config, stmt); err != nil {
+				return translateError(err, config, "Cannot cancel job %s", jobName)
+			}
+			cmd.Printf("Job %s is cancelled\n", jobName)
+			return nil
+		},
+	}
+	decorateCommandWithJobNameFlag(cmd, &jobName)
+	cmd.Flags().StringVar(&snapshotName, SnapshotFlag, "", "export a snapshot with the given name before cancelling the job [Enterprise]")
+	return cmd
+}
+
+// NewAlter creates a command for the given ALTER JOB operation, one of suspend, resume or restart.
+func NewAlter(config *hazelcast.Config, operation, info string) *cobra.Command {
+	var jobName string
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s --name jobname", operation),
+		Short:   info,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stmt := fmt.Sprintf("ALTER JOB %s %s", sqlcmd.QuoteIdentifier(jobName), strings.ToUpper(operation))
+			if err := executeStatement(cmd.Context(), config, stmt); err != nil {
+				return translateError(err, config, "Cannot %s job %s", operation, jobName)
+			}
+			cmd.Printf("Job %s: %s requested\n", jobName, operation)
+			return nil
+		},
+	}
+	decorateCommandWithJobNameFlag(cmd, &jobName)
+	return cmd
+}
+
+func NewExportSnapshot(config *hazelcast.Config) *cobra.Command {
+	var jobName, snapshotName string
+	cmd := &cobra.Command{
+		Use:     "export-snapshot --name jobname --snapshot snapshotname",
+		Short:   "Export a named snapshot of the job, replacing the snapshot with the same name [Enterprise]",
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stmt := fmt.Sprintf("CREATE OR REPLACE SNAPSHOT %s FOR JOB %s", sqlcmd.QuoteIdentifier(snapshotName), sqlcmd.QuoteIdentifier(jobName))
+			if err := executeStatement(cmd.Context(), config, stmt); err != nil {
+				return translateError(err, config, "Cannot export snapshot %s of job %s", snapshotName, jobName)
+			}
+			cmd.Printf("Snapshot %s of job %s is exported\n", snapshotName, jobName)
+			return nil
+		},
+	}
+	decorateCommandWithJobNameFlag(cmd, &jobName)
+	cmd.Flags().StringVar(&snapshotName, SnapshotFlag, "", "name of the snapshot")
+	if err := cmd.MarkFlagRequired(SnapshotFlag); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func getClient(ctx context.Context, config *hazelcast.Config) (*hazelcast.Client, error) {
+	c, err := connection.ConnectToCluster(ctx, config)
+	if err != nil {
+		return nil, hzcerrors.NewLoggableError(err, "Cannot get initialize client")
+	}
+	return c, nil
+}
+
+func executeStatement(ctx context.Context, config *hazelcast.Config, stmt string) error {
+	c, err := getClient(ctx, config)
+	if err != nil {
+		return err
+	}
+	r, err := c.SQL().Execute(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	return r.Close()
+}
+
+func translateError(err error, config *hazelcast.Config, format string, a ...interface{}) error {
+	if msg, handled := hzcerrors.TranslateNetworkError(err, config.Cluster.Cloud.Enabled); handled {
+		return hzcerrors.NewLoggableError(err, msg)
+	}
+	var loggable hzcerrors.LoggableError
+	if errors.As(err, &loggable) {
+		return err
+	}
+	return hzcerrors.NewLoggableError(err, format, a...)
+}
+
+func decorateCommandWithJobNameFlag(cmd *cobra.Command, jobName *string) {
+	cmd.Flags().StringVarP(jobName, JobNameFlag, JobNameFlagShort, "", "name of the job")
+	if err := cmd.MarkFlagRequired(JobNameFlag); err != nil {
+		panic(err)
+	}
+}
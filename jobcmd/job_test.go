@@ -0,0 +1,34 @@
+package jobcmd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	hz "github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/it"
+	"github.com/hazelcast/hazelcast-commandline-client/jobcmd"
+)
+
+func TestJobList(t *testing.T) {
+	it.SQLTester(t, func(t *testing.T, client *hz.Client, config *hz.Config, m *hz.Map, mapName string) {
+		cmd := jobcmd.New(config)
+		var b bytes.Buffer
+		cmd.SetOut(&b)
+		cmd.SetArgs([]string{"list", "--output-type", "csv"})
+		_, err := cmd.ExecuteContextC(context.Background())
+		require.NoError(t, err)
+		require.Contains(t, b.String(), "name\n")
+	})
+}
+
+func TestJobCancel_RequiresName(t *testing.T) {
+	cmd := jobcmd.New(&hz.Config{})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"cancel"})
+	_, err := cmd.ExecuteContextC(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `required flag(s) "name" not set`)
+}
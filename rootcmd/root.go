@@ -27,7 +27,9 @@ import (
 
 	"github.com/hazelcast/hazelcast-commandline-client/clustercmd"
 	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/configcmd"
 	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/jobcmd"
 	"github.com/hazelcast/hazelcast-commandline-client/listobjectscmd"
 	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
 	fakeDoor "github.com/hazelcast/hazelcast-commandline-client/types/fakedoorcmd"
@@ -40,13 +42,16 @@ func New(cnfg *hazelcast.Config, isInteractiveInvocation bool) (*cobra.Command,
 	root := NewWithoutPersistentFlags(cnfg, isInteractiveInvocation)
 	var flags config.GlobalFlagValues
 	assignPersistentFlags(root, &flags)
+	// the config commands work on the configuration file given by the persistent flags,
+	// the interactive mode adds them with the flags it is started with
+	root.AddCommand(configcmd.New(&flags))
 	return root, &flags
 }
 
 // NewWithoutPersistentFlags initializes root command without the persistent flags
 func NewWithoutPersistentFlags(cnfg *hazelcast.Config, isInteractiveInvocation bool) *cobra.Command {
 	root := &cobra.Command{
-		Use:   "hzc {cluster | map | sql | version | help} [--address address | --cloud-token token | --cluster-name name | --config config]",
+		Use:   "hzc {cluster | map | sql | job | config | version | help} [--address address | --cloud-token token | --cluster-name name | --config config | --profile name]",
 		Short: "Hazelcast command-line client",
 		Long:  "Hazelcast command-line client connects your command-line to a Hazelcast cluster",
 		Example: `hzc # starts an interactive shell 🚀
@@ -84,8 +89,10 @@ hzc help # print help`,
 func subCommands(config *hazelcast.Config, isInteractiveInvocation bool) []*cobra.Command {
 	cmds := []*cobra.Command{
 		clustercmd.New(config),
+		clustercmd.NewMember(config),
 		mapcmd.New(config, isInteractiveInvocation),
 		sqlcmd.New(config),
+		jobcmd.New(config),
 		versioncmd.New(),
 		listobjectscmd.New(config),
 		connwizardcmd.New(),
@@ -110,6 +117,7 @@ func assignPersistentFlags(cmd *cobra.Command, flags *config.GlobalFlagValues) {
 	cmd.PersistentFlags().StringVarP(&flags.Address, "address", "a", "", fmt.Sprintf("addresses of the instances in the cluster (default is %s)", config.DefaultClusterAddress))
 	cmd.PersistentFlags().StringVar(&flags.Cluster, "cluster-name", "", fmt.Sprintf("name of the cluster that contains the instances (default is %s)", config.DefaultClusterName))
 	cmd.PersistentFlags().StringVar(&flags.Token, "cloud-token", "", "your Hazelcast Viridian token")
+	cmd.PersistentFlags().StringVar(&flags.Profile, "profile", "", "name of the profile in the config file to use (default is $CLC_PROFILE or the defaultprofile of the config file)")
 	cmd.PersistentFlags().BoolVar(&flags.Verbose, "verbose", false, "verbose output")
 	cmd.PersistentFlags().BoolVar(&flags.NoColor, "no-color", false, "disable colors")
 	cmd.PersistentFlags().BoolVar(&flags.NoAutocompletion, "no-completion", false, "disable completion [interactive mode]")
@@ -118,6 +126,13 @@ func assignPersistentFlags(cmd *cobra.Command, flags *config.GlobalFlagValues) {
 	cmd.RegisterFlagCompletionFunc("log-level", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return config.ValidLogLevels, cobra.ShellCompDirectiveDefault
 	})
+	cmd.RegisterFlagCompletionFunc("profile", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names, _, err := config.ProfileNames(flags.CfgFile)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
 
 }
 
@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package runner
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/configcmd"
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+)
+
+// interactiveCommands returns the commands which need the configuration and the flags of the interactive session.
+// connect replaces the configuration in place, so that the other commands and the prompt prefix, which refer to it,
+// use the new cluster.
+func interactiveCommands(cnfg *config.Config, flags *config.GlobalFlagValues) func() []*cobra.Command {
+	return func() []*cobra.Command {
+		return []*cobra.Command{newConnectCmd(cnfg, flags), newDisconnectCmd(cnfg), configcmd.New(flags)}
+	}
+}
+
+func newConnectCmd(cnfg *config.Config, flags *config.GlobalFlagValues) *cobra.Command {
+	var address, cluster, token string
+	cmd := &cobra.Command{
+		Use:   "connect [profile] [--address address] [--cluster-name name] [--cloud-token token]",
+		Short: "Connect to another cluster",
+		Long: `Disconnect from the current cluster and connect to the cluster of the given profile in the configuration file, or to the given addresses and cluster name.
+The flags override the profile, as they do when starting hzc, the profile hzc is started with is used if no profile is given. Without a profile and flags, connect to the last cluster again, e.g. after disconnect.`,
+		Example: `  connect prod
+  connect --address 192.168.1.2:5701 --cluster-name dev
+  connect`,
+		Args:    cobra.MaximumNArgs(1),
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			next := *cnfg
+			if len(args) > 0 || address != "" || cluster != "" || token != "" {
+				gfv := config.GlobalFlagValues{
+					CfgFile:          flags.CfgFile,
+					Profile:          flags.Profile,
+					Address:          address,
+					Cluster:          cluster,
+					Token:            token,
+					Verbose:          flags.Verbose,
+					NoColor:          flags.NoColor,
+					NoAutocompletion: flags.NoAutocompletion,
+					LogLevel:         flags.LogLevel,
+				}
+				if len(args) > 0 {
+					gfv.Profile = args[0]
+				}
+				var err error
+				// the current connection is kept if the configuration is not valid
+				if next, err = connectionConfig(cnfg, &gfv); err != nil {
+					return err
+				}
+			}
+			if err := connection.Disconnect(cmd.Context()); err != nil {
+				cmd.PrintErrf("Could not disconnect from the cluster gracefully: %s\n", err)
+			}
+			*cnfg = next
+			if cnfg.Hazelcast.Cluster.Cloud.Enabled {
+				if err := setDefaultCoordinator(); err != nil {
+					return err
+				}
+			}
+			if _, err := connection.Connect(cmd.Context(), &cnfg.Hazelcast); err != nil {
+				return err
+			}
+			cmd.Printf("Connected to the cluster %q at %s\n", cnfg.Hazelcast.Cluster.Name, config.GetClusterAddress(&cnfg.Hazelcast))
+			return nil
+		},
+	}
+	// the root command has no persistent flags in the interactive mode, so they are defined on the command
+	cmd.Flags().StringVarP(&address, "address", "a", "", "addresses of the instances in the cluster, separated by commas")
+	cmd.Flags().StringVar(&cluster, "cluster-name", "", "name of the cluster that contains the instances")
+	cmd.Flags().StringVar(&token, "cloud-token", "", "your Hazelcast Viridian token")
+	return cmd
+}
+
+// connectionConfig reads the configuration file again with the given profile and flags. The logger of the session is
+// kept, since its output and level are set when hzc starts.
+func connectionConfig(current *config.Config, flags *config.GlobalFlagValues) (config.Config, error) {
+	c := config.DefaultConfig()
+	if err := config.ReadAndMergeWithFlags(flags, &c); err != nil {
+		return c, err
+	}
+	c.Hazelcast.Logger = current.Hazelcast.Logger
+	return c, nil
+}
+
+func newDisconnectCmd(cnfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:     "disconnect",
+		Short:   "Disconnect from the cluster",
+		Long:    `Gracefully shut down the connection to the cluster, use connect to connect to a cluster again.`,
+		Args:    cobra.NoArgs,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if connection.Disconnected() {
+				cmd.Println("Not connected to a cluster")
+				return nil
+			}
+			if err := connection.Disconnect(cmd.Context()); err != nil {
+				return hzcerrors.NewLoggableError(err, "Could not disconnect from the cluster gracefully: %s", err)
+			}
+			cmd.Printf("Disconnected from the cluster %q\n", cnfg.Hazelcast.Cluster.Name)
+			return nil
+		},
+	}
+}
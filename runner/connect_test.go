@@ -0,0 +1,77 @@
+package runner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/config"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+)
+
+const profilesConfig = `hazelcast:
+  cluster:
+    name: dev
+profiles:
+  prod:
+    hazelcast:
+      cluster:
+        name: prod
+        network:
+          addresses:
+            - 10.0.0.1:5701
+`
+
+func TestConnectionConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profilesConfig), 0600))
+	current := config.DefaultConfig()
+	current.Hazelcast.Logger.Level = ""
+	c, err := connectionConfig(&current, &config.GlobalFlagValues{CfgFile: path, Profile: "prod"})
+	require.NoError(t, err)
+	require.Equal(t, "prod", c.Profile)
+	require.Equal(t, "prod", c.Hazelcast.Cluster.Name)
+	require.Equal(t, []string{"10.0.0.1:5701"}, c.Hazelcast.Cluster.Network.Addresses)
+	// the logger of the session is kept
+	require.Equal(t, current.Hazelcast.Logger, c.Hazelcast.Logger)
+	c, err = connectionConfig(&current, &config.GlobalFlagValues{CfgFile: path, Profile: "prod", Cluster: "test", Address: "10.0.0.2:5701"})
+	require.NoError(t, err)
+	require.Equal(t, "test", c.Hazelcast.Cluster.Name)
+	require.Equal(t, []string{"10.0.0.2:5701"}, c.Hazelcast.Cluster.Network.Addresses)
+}
+
+func TestConnect_InvalidProfile(t *testing.T) {
+	defer connection.ResetClient()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profilesConfig), 0600))
+	current := config.DefaultConfig()
+	cmd := newConnectCmd(&current, &config.GlobalFlagValues{CfgFile: path})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"staging"})
+	cmd.SilenceUsage = true
+	require.EqualError(t, cmd.Execute(), `profile "staging" is not found in `+path+`, available profiles: prod`)
+	// the current connection is kept
+	require.False(t, connection.Disconnected())
+	require.Equal(t, config.DefaultClusterName, current.Hazelcast.Cluster.Name)
+}
+
+func TestConnect_FlagsKeepStartupProfile(t *testing.T) {
+	defer connection.ResetClient()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profilesConfig), 0600))
+	current := config.DefaultConfig()
+	cmd := newConnectCmd(&current, &config.GlobalFlagValues{CfgFile: path, Profile: "staging"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--cluster-name", "test"})
+	cmd.SilenceUsage = true
+	// the profile given when starting hzc is read again, though only the flags are given
+	require.EqualError(t, cmd.Execute(), `profile "staging" is not found in `+path+`, available profiles: prod`)
+	require.False(t, connection.Disconnected())
+}
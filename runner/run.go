@@ -48,7 +48,7 @@ func CLC(programArgs []string, stdin io.Reader, stdout io.Writer, stderr io.Writ
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	if isInteractive {
-		prompt, err := RunCmdInteractively(ctx, &cfg, logger, rootCmd, globalFlagValues.NoColor)
+		prompt, err := RunCmdInteractively(ctx, &cfg, logger, rootCmd, globalFlagValues)
 		if err != nil {
 			return logger, hzcerrors.NewLoggableError(err, "")
 		}
@@ -82,7 +82,7 @@ func IsInteractiveCall(rootCmd *cobra.Command, args []string) bool {
 	return false
 }
 
-func RunCmdInteractively(ctx context.Context, cnfg *config.Config, l log.Logger, rootCmd *cobra.Command, noColor bool) (cobraprompt.GoPromptWithGracefulShutdown, error) {
+func RunCmdInteractively(ctx context.Context, cnfg *config.Config, l log.Logger, rootCmd *cobra.Command, globalFlagValues *config.GlobalFlagValues) (cobraprompt.GoPromptWithGracefulShutdown, error) {
 	cmdHistoryPath := filepath.Join(file.HZCHomePath(), "history")
 	exists, err := file.Exists(cmdHistoryPath)
 	if err != nil {
@@ -101,11 +101,15 @@ func RunCmdInteractively(ctx context.Context, cnfg *config.Config, l log.Logger,
 		SuggestFlagsWithoutDash:  true,
 		DisableCompletionCommand: true,
 		DisableSuggestions:       cnfg.NoAutocompletion,
-		NoColor:                  noColor,
+		NoColor:                  globalFlagValues.NoColor,
 		AddDefaultExitCommand:    true,
 		GoPromptOptions: []goprompt.Option{
 			goprompt.OptionTitle("Hazelcast Client"),
 			goprompt.OptionLivePrefix(func() (prefix string, useLivePrefix bool) {
+				// the configuration is replaced by connect, hence the prefix is built on every input
+				if connection.Disconnected() {
+					return "hzc (disconnected)> ", true
+				}
 				var b strings.Builder
 				for k, v := range namePersister {
 					b.WriteString(fmt.Sprintf("&%c:%s", k[0], v))
@@ -120,7 +124,8 @@ func RunCmdInteractively(ctx context.Context, cnfg *config.Config, l log.Logger,
 			rootCmd.PrintErrln(errStr)
 			return
 		},
-		Persister: namePersister,
+		Persister:           namePersister,
+		InteractiveCommands: interactiveCommands(cnfg, globalFlagValues),
 	}
 	if _, err = connection.ConnectToClusterInteractive(ctx, hConfig); err != nil {
 		// ignore error coming from the connection spinner
@@ -152,7 +157,7 @@ func ProcessConfigAndFlags(rootCmd *cobra.Command, cnfg *config.Config, programA
 		return defaultLogger, err
 	}
 	// initialize config from file
-	if err = config.ReadAndMergeWithFlags(globalFlagValues, cnfg); err != nil {
+	if err = config.ReadAndMergeWithFlags(globalFlagValues, cnfg); err != nil && !isConfigOptional(subCmd) {
 		return defaultLogger, err
 	}
 	l, err := config.SetupLogger(cnfg, globalFlagValues, os.Stderr)
@@ -169,6 +174,16 @@ func ProcessConfigAndFlags(rootCmd *cobra.Command, cnfg *config.Config, programA
 	return defaultLogger, nil
 }
 
+// isConfigOptional returns true if the command or one of its parents is annotated with config.OptionalConfigAnnotation.
+func isConfigOptional(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[config.OptionalConfigAnnotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func setDefaultCoordinator() error {
 	if os.Getenv(EnvHzCloudCoordinatorBaseURL) != "" {
 		return nil
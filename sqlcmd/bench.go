@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+)
+
+const BenchExample = `  # Run the query 1000 times over 8 concurrent workers, after 50 warm-up runs.
+  hzc sql bench "SELECT * FROM employees WHERE age > 30" --iterations 1000 --concurrency 8 --warmup 50
+  # Save the latency of each run to compare it with another mapping later.
+  hzc sql bench "SELECT * FROM employees WHERE age > 30" --timings-file with-index.csv`
+
+type benchOptions struct {
+	iterations  int
+	concurrency int
+	warmup      int
+}
+
+// benchRun is the outcome of a single measured execution of the query.
+type benchRun struct {
+	iteration int
+	latency   time.Duration
+	// rows is the update count instead of the row count if isUpdate is set, e.g. for INSERT and DELETE
+	rows     int64
+	isUpdate bool
+}
+
+type benchReport struct {
+	runs    []benchRun
+	elapsed time.Duration
+}
+
+func NewBench(config *hazelcast.Config) *cobra.Command {
+	var (
+		opts        benchOptions
+		timingsFile string
+	)
+	cmd := &cobra.Command{
+		Use:     "bench [query] [--iterations count] [--concurrency count] [--warmup count]",
+		Short:   "Run the query repeatedly and report its throughput and latency",
+		Example: BenchExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.iterations < 1 || opts.concurrency < 1 || opts.warmup < 0 {
+				return hzcerrors.NewLoggableError(nil, "--iterations and --concurrency must be positive, --warmup must not be negative")
+			}
+			q := strings.TrimSpace(strings.Join(args, " "))
+			ctx := cmd.Context()
+			c, err := connection.ConnectToCluster(ctx, config)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+			}
+			if opts.warmup > 0 {
+				if _, err := runBench(ctx, c, q, opts.warmup, opts.concurrency); err != nil {
+					return benchError(err)
+				}
+			}
+			report, err := runBench(ctx, c, q, opts.iterations, opts.concurrency)
+			if err != nil {
+				return benchError(err)
+			}
+			report.print(cmd.OutOrStdout(), opts)
+			if timingsFile == "" {
+				return nil
+			}
+			if err := report.writeTimings(timingsFile); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot write the timings to %s", timingsFile)
+			}
+			return nil
+		},
+	}
+	flags := cmd.Flags()
+	flags.IntVar(&opts.iterations, "iterations", 100, "number of measured runs of the query")
+	flags.IntVar(&opts.concurrency, "concurrency", 1, "number of runs in parallel")
+	flags.IntVar(&opts.warmup, "warmup", 0, "number of runs before the measurement, which are not reported")
+	flags.StringVar(&timingsFile, "timings-file", "", "write the latency of each run to the given CSV file")
+	return cmd
+}
+
+func benchError(err error) error {
+	if IsContextCancellationErr(err) {
+		return nil
+	}
+	return hzcerrors.NewLoggableError(err, "Cannot execute the query")
+}
+
+// runBench runs the query the given number of times over concurrent workers, stopping at the first error.
+func runBench(ctx context.Context, c *hazelcast.Client, text string, iterations, concurrency int) (*benchReport, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	next := make(chan int)
+	runs := make([]benchRun, iterations)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	start := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				runStart := time.Now()
+				rows, isUpdate, err := drainQuery(ctx, c, text)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				runs[i] = benchRun{iteration: i + 1, latency: time.Since(runStart), rows: rows, isUpdate: isUpdate}
+			}
+		}()
+	}
+loop:
+	for i := 0; i < iterations; i++ {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+	close(next)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &benchReport{runs: runs, elapsed: time.Since(start)}, nil
+}
+
+// drainQuery executes the query and reads all of its rows, so that the measurement includes fetching the result.
+// It returns the update count instead of the row count for the statements which do not return rows.
+func drainQuery(ctx context.Context, c *hazelcast.Client, text string) (rows int64, isUpdate bool, err error) {
+	result, err := c.SQL().Execute(ctx, text)
+	if err != nil {
+		return 0, false, err
+	}
+	defer result.Close()
+	if !result.IsRowSet() {
+		return result.UpdateCount(), true, nil
+	}
+	it, err := result.Iterator()
+	if err != nil {
+		return 0, false, fmt.Errorf("initializing result iterator: %w", err)
+	}
+	for it.HasNext() {
+		if _, err := it.Next(); err != nil {
+			return 0, false, fmt.Errorf("fetching row: %w", err)
+		}
+		rows++
+	}
+	return rows, false, nil
+}
+
+// isUpdate reports whether the runs are of a statement which returns the update count instead of rows, so that
+// the statements are not reported as queries returning rows.
+func (r *benchReport) isUpdate() bool {
+	return len(r.runs) > 0 && r.runs[0].isUpdate
+}
+
+// percentile returns the nearest-rank percentile of the sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+func (r *benchReport) print(out io.Writer, opts benchOptions) {
+	latencies := make([]time.Duration, len(r.runs))
+	var rows int64
+	for i, run := range r.runs {
+		latencies[i] = run.latency
+		rows += run.rows
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	n := float64(len(r.runs))
+	fmt.Fprintf(out, "Iterations:  %d (concurrency %d, warmup %d)\n", len(r.runs), opts.concurrency, opts.warmup)
+	fmt.Fprintf(out, "Elapsed:     %s\n", r.elapsed.Truncate(time.Millisecond))
+	if r.isUpdate() {
+		fmt.Fprintf(out, "Throughput:  %.1f statements/sec\n", n/r.elapsed.Seconds())
+		fmt.Fprintf(out, "Updates:     %.1f per statement\n", float64(rows)/n)
+	} else {
+		fmt.Fprintf(out, "Throughput:  %.1f queries/sec\n", n/r.elapsed.Seconds())
+		fmt.Fprintf(out, "Rows/query:  %.1f\n", float64(rows)/n)
+	}
+	fmt.Fprintf(out, "Latency:     p50 %s, p90 %s, p99 %s, max %s\n",
+		formatLatency(percentile(latencies, 50)),
+		formatLatency(percentile(latencies, 90)),
+		formatLatency(percentile(latencies, 99)),
+		formatLatency(latencies[len(latencies)-1]))
+}
+
+func formatLatency(d time.Duration) string {
+	return d.Truncate(time.Microsecond).String()
+}
+
+// writeTimings writes the latency of each run in milliseconds, in the order of the runs.
+func (r *benchReport) writeTimings(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	countColumn := "rows"
+	if r.isUpdate() {
+		countColumn = "updates"
+	}
+	_ = w.Write([]string{"iteration", "latency_ms", countColumn})
+	for _, run := range r.runs {
+		_ = w.Write([]string{
+			strconv.Itoa(run.iteration),
+			strconv.FormatFloat(float64(run.latency)/float64(time.Millisecond), 'f', 3, 64),
+			strconv.FormatInt(run.rows, 10),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
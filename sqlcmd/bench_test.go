@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+	require.Equal(t, 50*time.Millisecond, percentile(sorted, 50))
+	require.Equal(t, 90*time.Millisecond, percentile(sorted, 90))
+	require.Equal(t, 99*time.Millisecond, percentile(sorted, 99))
+	require.Equal(t, 7*time.Millisecond, percentile([]time.Duration{7 * time.Millisecond}, 99))
+	require.Equal(t, time.Duration(0), percentile(nil, 50))
+}
+
+func TestBenchReport(t *testing.T) {
+	r := &benchReport{
+		runs: []benchRun{
+			{iteration: 1, latency: 2 * time.Millisecond, rows: 10},
+			{iteration: 2, latency: 1500 * time.Microsecond, rows: 20},
+		},
+		elapsed: time.Second,
+	}
+	var out bytes.Buffer
+	r.print(&out, benchOptions{iterations: 2, concurrency: 1})
+	require.Contains(t, out.String(), "Throughput:  2.0 queries/sec")
+	require.Contains(t, out.String(), "Rows/query:  15.0")
+	require.Contains(t, out.String(), "p50 1.5ms, p90 2ms, p99 2ms, max 2ms")
+	path := filepath.Join(t.TempDir(), "timings.csv")
+	require.NoError(t, r.writeTimings(path))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "iteration,latency_ms,rows\n1,2.000,10\n2,1.500,20\n", string(b))
+}
+
+func TestBenchReport_Updates(t *testing.T) {
+	r := &benchReport{
+		runs: []benchRun{
+			{iteration: 1, latency: 2 * time.Millisecond, rows: 1, isUpdate: true},
+			{iteration: 2, latency: 2 * time.Millisecond, rows: 1, isUpdate: true},
+		},
+		elapsed: time.Second,
+	}
+	var out bytes.Buffer
+	r.print(&out, benchOptions{iterations: 2, concurrency: 1})
+	require.Contains(t, out.String(), "Throughput:  2.0 statements/sec")
+	require.Contains(t, out.String(), "Updates:     1.0 per statement")
+	require.NotContains(t, out.String(), "Rows/query")
+	path := filepath.Join(t.TempDir(), "timings.csv")
+	require.NoError(t, r.writeTimings(path))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "iteration,latency_ms,updates\n1,2.000,1\n2,2.000,1\n", string(b))
+}
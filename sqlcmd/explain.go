@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/explain"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/tuiutil"
+)
+
+const ExplainExample = `  # Show how the query is executed, the operators scanning all entries of a map are marked as FULL SCAN.
+  hzc sql explain "SELECT * FROM employees WHERE age > 30"`
+
+func NewExplain(config *hazelcast.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "explain [query]",
+		Short:   "Show the execution plan of the query as a tree",
+		Example: ExplainExample,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			q := strings.TrimSpace(strings.Join(args, " "))
+			ctx := cmd.Context()
+			c, err := connection.ConnectToCluster(ctx, config)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize SQL driver")
+			}
+			plan, err := explain.Plan(ctx, c, q)
+			if err != nil {
+				if IsContextCancellationErr(err) {
+					return nil
+				}
+				return hzcerrors.NewLoggableError(err, "Cannot explain the query")
+			}
+			cmd.Print(explain.Render(plan, tuiutil.SelectedTheme != tuiutil.NoColor))
+			return nil
+		},
+	}
+	return cmd
+}
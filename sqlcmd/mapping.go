@@ -0,0 +1,449 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/spf13/cobra"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+)
+
+const GenerateMappingExample = `  # Print the mapping for the map "employees" inferred from 100 sampled entries.
+  hzc sql generate-mapping --map employees
+  # Create the mapping right away, giving the format of the values instead of inferring it.
+  hzc sql generate-mapping --map employees --value-format compact:Employee --execute
+  # Replace the existing mapping of the map.
+  hzc sql generate-mapping --map employees --execute --replace`
+
+const (
+	formatJSONFlat = "json-flat"
+	formatJSON     = "json"
+	formatPortable = "portable"
+	formatCompact  = "compact"
+)
+
+// SQL types of the inferred columns, in the order of widening for numeric types.
+const (
+	sqlTinyint         = "TINYINT"
+	sqlSmallint        = "SMALLINT"
+	sqlInteger         = "INTEGER"
+	sqlBigint          = "BIGINT"
+	sqlReal            = "REAL"
+	sqlDouble          = "DOUBLE"
+	sqlDecimal         = "DECIMAL"
+	sqlVarchar         = "VARCHAR"
+	sqlBoolean         = "BOOLEAN"
+	sqlDate            = "DATE"
+	sqlTime            = "TIME"
+	sqlTimestamp       = "TIMESTAMP"
+	sqlTimestampWithTZ = "TIMESTAMP WITH TIME ZONE"
+	sqlJSON            = "JSON"
+)
+
+// numericWidening ranks the numeric types, DECIMAL is the widest since it represents the others without losing precision.
+var numericWidening = map[string]int{
+	sqlTinyint:  1,
+	sqlSmallint: 2,
+	sqlInteger:  3,
+	sqlBigint:   4,
+	sqlReal:     5,
+	sqlDouble:   6,
+	sqlDecimal:  7,
+}
+
+type mappingColumn struct {
+	name    string
+	sqlType string
+}
+
+// portableObject and compactObject are sampled objects which the CLC cannot deserialize.
+// Their formats are inferred from the serialized objects.
+type portableObject struct {
+	factoryID int32
+	classID   int32
+	version   int32
+}
+
+func (p portableObject) String() string {
+	return fmt.Sprintf("%s:%d:%d:%d", formatPortable, p.factoryID, p.classID, p.version)
+}
+
+type compactObject struct {
+	typeName string
+}
+
+func (c compactObject) String() string {
+	return fmt.Sprintf("%s:%s", formatCompact, c.typeName)
+}
+
+// serializationFormat is the inferred or given format of a key or value, along with its columns.
+type serializationFormat struct {
+	// name is the value of the keyFormat or valueFormat option
+	name string
+	// options are the extra mapping options, such as the compact type name
+	options [][2]string
+	columns []mappingColumn
+}
+
+func NewGenerateMapping(config *hazelcast.Config) *cobra.Command {
+	var (
+		mapName, mappingName   string
+		keyFormat, valueFormat string
+		sampleSize             int
+		executeMapping         bool
+		replaceMapping         bool
+	)
+	cmd := &cobra.Command{
+		Use:     "generate-mapping --map mapname [--sample count] [--execute [--replace]]",
+		Short:   "Generate the CREATE MAPPING statement of a map by sampling its entries",
+		Example: GenerateMappingExample,
+		PreRunE: hzcerrors.RequiredFlagChecker,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if sampleSize <= 0 {
+				return hzcerrors.NewLoggableError(nil, "--sample must be positive")
+			}
+			keyFmt, err := parseFormatFlag(keyFormat, "key")
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid --key-format: %s", err)
+			}
+			valueFmt, err := parseFormatFlag(valueFormat, "value")
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Invalid --value-format: %s", err)
+			}
+			ctx := cmd.Context()
+			c, err := connection.ConnectToCluster(ctx, config)
+			if err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot get initialize client")
+			}
+			keys, values, err := sampleMap(ctx, c, mapName, sampleSize, keyFmt == nil, valueFmt == nil)
+			if err != nil {
+				return err
+			}
+			if keyFmt == nil {
+				if keyFmt, err = inferFormat(keys, "__key", "key"); err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot infer the key format of map %s: %s", mapName, err)
+				}
+			}
+			if valueFmt == nil {
+				if valueFmt, err = inferFormat(values, "this", "value"); err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot infer the value format of map %s: %s", mapName, err)
+				}
+			}
+			if mappingName == "" {
+				mappingName = mapName
+			}
+			ddl := mappingDDL(mappingName, mapName, keyFmt, valueFmt, replaceMapping)
+			if !executeMapping {
+				cmd.Println(ddl)
+				return nil
+			}
+			if _, err := execute(ctx, c, ddl); err != nil {
+				return hzcerrors.NewLoggableError(err, "Cannot create the mapping:\n%s", ddl)
+			}
+			cmd.Printf("Created mapping %s for map %s\n", mappingName, mapName)
+			return nil
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&mapName, "map", "m", "", "name of the map to sample")
+	flags.IntVar(&sampleSize, "sample", 100, "number of entries to sample")
+	flags.StringVar(&mappingName, "mapping-name", "", "name of the mapping (default is the map name)")
+	flags.StringVar(&keyFormat, "key-format", "", "format of Compact or Portable keys instead of the inferred one: compact:TypeName or portable:factoryID:classID[:version]")
+	flags.StringVar(&valueFormat, "value-format", "", "format of Compact or Portable values instead of the inferred one: compact:TypeName or portable:factoryID:classID[:version]")
+	flags.BoolVar(&executeMapping, "execute", false, "create the mapping instead of printing it")
+	flags.BoolVar(&replaceMapping, "replace", false, "replace the mapping if it exists, instead of failing")
+	if err := cmd.MarkFlagRequired("map"); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+// parseFormatFlag parses the format given as compact:TypeName or portable:factoryID:classID[:version].
+// A nil format is returned if the flag is not set, so that the format is inferred.
+func parseFormatFlag(flag, prefix string) (*serializationFormat, error) {
+	if flag == "" {
+		return nil, nil
+	}
+	parts := strings.Split(flag, ":")
+	switch strings.ToLower(parts[0]) {
+	case formatCompact:
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.New("compact format must be given as compact:TypeName")
+		}
+		return &serializationFormat{
+			name:    formatCompact,
+			options: [][2]string{{prefix + "CompactTypeName", parts[1]}},
+		}, nil
+	case formatPortable:
+		if len(parts) != 3 && len(parts) != 4 {
+			return nil, errors.New("portable format must be given as portable:factoryID:classID[:version]")
+		}
+		f := &serializationFormat{
+			name: formatPortable,
+			options: [][2]string{
+				{prefix + "PortableFactoryId", parts[1]},
+				{prefix + "PortableClassId", parts[2]},
+			},
+		}
+		if len(parts) == 4 {
+			f.options = append(f.options, [2]string{prefix + "PortableClassVersion", parts[3]})
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("unknown format %s, only compact and portable formats can be given, the others are inferred", flag)
+}
+
+// inferFormat infers the format and the columns from the sampled objects.
+// Primitive objects are mapped to a single column with the given name, JSON objects are mapped to one column per field.
+// The columns of Compact and Portable objects are resolved by the member, prefix is the prefix of their options.
+func inferFormat(samples []interface{}, columnName, prefix string) (*serializationFormat, error) {
+	var sqlType string
+	var jsonSamples []serialization.JSON
+	var serialized fmt.Stringer
+	for _, s := range samples {
+		switch v := s.(type) {
+		case nil:
+			continue
+		case serialization.JSON:
+			jsonSamples = append(jsonSamples, v)
+			continue
+		case portableObject, compactObject:
+			f := v.(fmt.Stringer)
+			if serialized != nil && serialized != f {
+				return nil, fmt.Errorf("sampled objects have mixed formats %s and %s", serialized, f)
+			}
+			serialized = f
+			continue
+		}
+		t, err := sqlTypeOf(s)
+		if err != nil {
+			return nil, err
+		}
+		if sqlType, err = mergeSQLTypes(sqlType, t); err != nil {
+			return nil, err
+		}
+	}
+	if len(jsonSamples) > 0 && sqlType != "" {
+		return nil, fmt.Errorf("sampled objects are mixed JSON and %s", sqlType)
+	}
+	if serialized != nil {
+		if len(jsonSamples) > 0 || sqlType != "" {
+			return nil, fmt.Errorf("sampled objects are mixed %s and other types", serialized)
+		}
+		return parseFormatFlag(serialized.String(), prefix)
+	}
+	if len(jsonSamples) > 0 {
+		return inferJSONFormat(jsonSamples, columnName)
+	}
+	if sqlType == "" {
+		return nil, errors.New("all sampled objects are null")
+	}
+	return &serializationFormat{
+		name:    strings.ToLower(sqlType),
+		columns: []mappingColumn{{name: columnName, sqlType: sqlType}},
+	}, nil
+}
+
+func sqlTypeOf(v interface{}) (string, error) {
+	switch v.(type) {
+	case string:
+		return sqlVarchar, nil
+	case bool:
+		return sqlBoolean, nil
+	case int8:
+		return sqlTinyint, nil
+	case int16:
+		return sqlSmallint, nil
+	case int32:
+		return sqlInteger, nil
+	case int64:
+		return sqlBigint, nil
+	case float32:
+		return sqlReal, nil
+	case float64:
+		return sqlDouble, nil
+	case types.Decimal:
+		return sqlDecimal, nil
+	case types.LocalDate:
+		return sqlDate, nil
+	case types.LocalTime:
+		return sqlTime, nil
+	case types.LocalDateTime:
+		return sqlTimestamp, nil
+	case types.OffsetDateTime:
+		return sqlTimestampWithTZ, nil
+	}
+	return "", fmt.Errorf("objects of type %T cannot be mapped", v)
+}
+
+// mergeSQLTypes returns the type which can represent both types, widening numeric types if necessary.
+// Empty type stands for no type inferred yet.
+func mergeSQLTypes(current, next string) (string, error) {
+	if current == "" || current == next {
+		return next, nil
+	}
+	cw, cok := numericWidening[current]
+	nw, nok := numericWidening[next]
+	if !cok || !nok {
+		return "", fmt.Errorf("sampled objects have mixed types %s and %s", current, next)
+	}
+	if nw > cw {
+		return next, nil
+	}
+	return current, nil
+}
+
+// inferJSONFormat maps JSON objects with only scalar fields to json-flat, and everything else to a single JSON column.
+func inferJSONFormat(samples []serialization.JSON, columnName string) (*serializationFormat, error) {
+	asJSON := &serializationFormat{
+		name:    formatJSON,
+		columns: []mappingColumn{{name: columnName, sqlType: sqlJSON}},
+	}
+	var columns []mappingColumn
+	index := map[string]int{}
+	for _, s := range samples {
+		fields, ok := flatJSONFields(s)
+		if !ok {
+			return asJSON, nil
+		}
+		for _, f := range fields {
+			i, seen := index[f.name]
+			if !seen {
+				index[f.name] = len(columns)
+				columns = append(columns, f)
+				continue
+			}
+			merged, err := mergeSQLTypes(columns[i].sqlType, f.sqlType)
+			if err != nil {
+				// json-flat converts the values, so fall back to the most general type
+				merged = sqlVarchar
+			}
+			columns[i].sqlType = merged
+		}
+	}
+	for i, c := range columns {
+		if c.sqlType == "" {
+			// the field is null in all samples
+			columns[i].sqlType = sqlVarchar
+		}
+	}
+	return &serializationFormat{name: formatJSONFlat, columns: columns}, nil
+}
+
+// flatJSONFields returns the fields of the JSON object in the order they appear.
+// It returns false if the JSON is not an object or has nested objects or arrays.
+func flatJSONFields(j serialization.JSON) ([]mappingColumn, bool) {
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.UseNumber()
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, false
+	}
+	var fields []mappingColumn
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		name := t.(string)
+		v, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		var sqlType string
+		switch tv := v.(type) {
+		case json.Delim:
+			// nested object or array
+			return nil, false
+		case string:
+			sqlType = sqlVarchar
+		case bool:
+			sqlType = sqlBoolean
+		case json.Number:
+			sqlType = sqlBigint
+			if _, err := tv.Int64(); err != nil {
+				sqlType = sqlDouble
+			}
+		case nil:
+			// unknown until a non-null value is seen
+		}
+		fields = append(fields, mappingColumn{name: name, sqlType: sqlType})
+	}
+	return fields, true
+}
+
+// mappingDDL generates the CREATE MAPPING statement, or CREATE OR REPLACE MAPPING if replace is set.
+// Columns are omitted for formats without inferred columns, so that the member resolves them.
+func mappingDDL(mappingName, mapName string, key, value *serializationFormat, replace bool) string {
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if replace {
+		b.WriteString("OR REPLACE ")
+	}
+	b.WriteString(fmt.Sprintf("MAPPING %s", QuoteIdentifier(mappingName)))
+	if mappingName != mapName {
+		b.WriteString(fmt.Sprintf(" EXTERNAL NAME %s", QuoteIdentifier(mapName)))
+	}
+	var columns []string
+	for _, c := range key.columns {
+		if c.name == "__key" {
+			columns = append(columns, fmt.Sprintf("    __key %s", c.sqlType))
+			continue
+		}
+		columns = append(columns, fmt.Sprintf("    %s %s EXTERNAL NAME %s", QuoteIdentifier(c.name), c.sqlType, QuoteIdentifier("__key."+c.name)))
+	}
+	for _, c := range value.columns {
+		if c.name == "this" {
+			columns = append(columns, fmt.Sprintf("    this %s", c.sqlType))
+			continue
+		}
+		columns = append(columns, fmt.Sprintf("    %s %s", QuoteIdentifier(c.name), c.sqlType))
+	}
+	// once columns are given the member does not resolve the rest, so either give all columns or none
+	if len(key.columns) > 0 && len(value.columns) > 0 {
+		b.WriteString(" (\n")
+		b.WriteString(strings.Join(columns, ",\n"))
+		b.WriteString("\n)")
+	}
+	b.WriteString("\nTYPE IMap\nOPTIONS (\n")
+	options := [][2]string{{"keyFormat", key.name}}
+	options = append(options, key.options...)
+	options = append(options, [2]string{"valueFormat", value.name})
+	options = append(options, value.options...)
+	for i, o := range options {
+		b.WriteString(fmt.Sprintf("    '%s' = '%s'", o[0], strings.ReplaceAll(o[1], "'", "''")))
+		if i < len(options)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,75 @@
+//go:build !hazelcastinternal
+// +build !hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"context"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+// maxKeySetSize is the size of the largest map whose key set is fetched to sample it.
+const maxKeySetSize = 10000
+
+// sampleMap returns up to count keys and values of the map.
+// The public API of the client cannot fetch a part of the map, so all keys are fetched, and the Compact and Portable
+// objects cannot be read. Maps larger than maxKeySetSize are not sampled. Building with the hazelcastinternal tag
+// fetches only the sampled entries and infers their formats.
+func sampleMap(ctx context.Context, c *hazelcast.Client, mapName string, count int, sampleKeys, sampleValues bool) ([]interface{}, []interface{}, error) {
+	const hint = "the entries are probably Compact or Portable objects, provide their format with --key-format and --value-format"
+	if !sampleKeys && !sampleValues {
+		return nil, nil, nil
+	}
+	m, err := c.GetMap(ctx, mapName)
+	if err != nil {
+		return nil, nil, hzcerrors.NewLoggableError(err, "Cannot get map %s", mapName)
+	}
+	size, err := m.Size(ctx)
+	if err != nil {
+		return nil, nil, hzcerrors.NewLoggableError(err, "Cannot get the size of map %s", mapName)
+	}
+	if size > maxKeySetSize {
+		return nil, nil, hzcerrors.NewLoggableError(nil, "Map %s has %d entries, which are too many to sample unless hzc is built with the hazelcastinternal tag, provide the formats with --key-format and --value-format", mapName, size)
+	}
+	keys, err := m.GetKeySet(ctx)
+	if err != nil {
+		return nil, nil, hzcerrors.NewLoggableError(err, "Cannot read the keys of map %s, %s", mapName, hint)
+	}
+	if len(keys) == 0 {
+		return nil, nil, hzcerrors.NewLoggableError(nil, "Map %s is empty, there is nothing to sample", mapName)
+	}
+	if len(keys) > count {
+		keys = keys[:count]
+	}
+	if !sampleValues {
+		return keys, nil, nil
+	}
+	entries, err := m.GetAll(ctx, keys...)
+	if err != nil {
+		return nil, nil, hzcerrors.NewLoggableError(err, "Cannot read the values of map %s, %s", mapName, hint)
+	}
+	values := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		values = append(values, e.Value)
+	}
+	return keys, values, nil
+}
@@ -0,0 +1,182 @@
+//go:build hazelcastinternal
+// +build hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/hazelcast/hazelcast-go-client"
+
+	hzcerrors "github.com/hazelcast/hazelcast-commandline-client/errors"
+)
+
+const (
+	// hex: 0x013800
+	mapFetchEntriesRequestType = int32(79872)
+	// hex: 0x001400
+	clientFetchSchemaRequestType = int32(5120)
+	// serialization type IDs of the objects which are not deserialized, but inferred from the serialized form
+	typePortable = -1
+	typeCompact  = -55
+	// the serialized object follows the partition hash and the type ID
+	dataOffset = 8
+)
+
+// sampleMap fetches up to count entries of the map partition by partition, so that only the sampled entries are
+// transferred. Compact and Portable objects are not deserialized, their formats are read from the serialized objects.
+func sampleMap(ctx context.Context, c *hazelcast.Client, mapName string, count int, sampleKeys, sampleValues bool) ([]interface{}, []interface{}, error) {
+	if !sampleKeys && !sampleValues {
+		return nil, nil, nil
+	}
+	ci := hazelcast.NewClientInternal(c)
+	// compact type names by schema ID
+	typeNames := map[int64]string{}
+	typeName := func(schemaID int64) (string, error) {
+		if name, ok := typeNames[schemaID]; ok {
+			return name, nil
+		}
+		resp, err := ci.InvokeOnRandomTarget(ctx, newFetchSchemaRequest(schemaID), nil)
+		if err != nil {
+			return "", fmt.Errorf("cannot fetch the schema of compact objects, provide their format with --key-format or --value-format: %w", err)
+		}
+		name, err := decodeFetchSchemaResponse(resp)
+		if err != nil {
+			return "", err
+		}
+		typeNames[schemaID] = name
+		return name, nil
+	}
+	var keys, values []interface{}
+	for partitionID := int32(0); partitionID < ci.PartitionCount() && len(keys) < count; partitionID++ {
+		resp, err := ci.InvokeOnPartition(ctx, newFetchEntriesRequest(mapName, partitionID, count-len(keys)), partitionID, nil)
+		if err != nil {
+			return nil, nil, hzcerrors.NewLoggableError(err, "Cannot read the entries of map %s", mapName)
+		}
+		for _, e := range decodeFetchEntriesResponse(resp) {
+			k, err := sampledObject(e.Key.(hazelcast.Data), ci.DecodeData, typeName)
+			if err != nil {
+				return nil, nil, hzcerrors.NewLoggableError(err, "Cannot read a key of map %s: %s", mapName, err)
+			}
+			v, err := sampledObject(e.Value.(hazelcast.Data), ci.DecodeData, typeName)
+			if err != nil {
+				return nil, nil, hzcerrors.NewLoggableError(err, "Cannot read a value of map %s: %s", mapName, err)
+			}
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, nil, hzcerrors.NewLoggableError(nil, "Map %s is empty, there is nothing to sample", mapName)
+	}
+	return keys, values, nil
+}
+
+// sampledObject returns portableObject or compactObject for the objects which the CLC cannot deserialize,
+// and the object deserialized with decode otherwise. typeName returns the type name of the compact schema.
+func sampledObject(data hazelcast.Data, decode func(hazelcast.Data) (interface{}, error), typeName func(schemaID int64) (string, error)) (interface{}, error) {
+	if len(data) < dataOffset {
+		return decode(data)
+	}
+	payload := data[dataOffset:]
+	switch data.Type() {
+	case typePortable:
+		if len(payload) < 12 {
+			return nil, errors.New("portable object is truncated")
+		}
+		return portableObject{
+			factoryID: int32(binary.BigEndian.Uint32(payload)),
+			classID:   int32(binary.BigEndian.Uint32(payload[4:])),
+			version:   int32(binary.BigEndian.Uint32(payload[8:])),
+		}, nil
+	case typeCompact:
+		if len(payload) < 8 {
+			return nil, errors.New("compact object is truncated")
+		}
+		name, err := typeName(int64(binary.BigEndian.Uint64(payload)))
+		if err != nil {
+			return nil, err
+		}
+		return compactObject{typeName: name}, nil
+	}
+	return decode(data)
+}
+
+// newFetchEntriesRequest encodes the MapFetchEntries request for up to batch entries from the start of the partition.
+func newFetchEntriesRequest(mapName string, partitionID int32, batch int) *hazelcast.ClientMessage {
+	req := hazelcast.NewClientMessageForEncode()
+	req.SetRetryable(true)
+	initial := make([]byte, hazelcast.PartitionIDOffset+2*hazelcast.IntSizeInBytes)
+	binary.LittleEndian.PutUint32(initial[hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes:], uint32(batch))
+	req.AddFrame(hazelcast.NewFrameWith(initial, hazelcast.UnfragmentedMessage))
+	req.SetMessageType(mapFetchEntriesRequestType)
+	req.SetPartitionId(partitionID)
+	req.AddFrame(hazelcast.NewFrame([]byte(mapName)))
+	// the iteration pointer which starts from the beginning of the partition: index and size
+	pointers := make([]byte, 2*hazelcast.IntSizeInBytes)
+	binary.LittleEndian.PutUint32(pointers, uint32(math.MaxInt32))
+	binary.LittleEndian.PutUint32(pointers[hazelcast.IntSizeInBytes:], uint32(0xFFFFFFFF))
+	req.AddFrame(hazelcast.NewFrame(pointers))
+	return req
+}
+
+// decodeFetchEntriesResponse returns the serialized entries of the MapFetchEntries response.
+func decodeFetchEntriesResponse(resp *hazelcast.ClientMessage) []hazelcast.Pair {
+	it := resp.FrameIterator()
+	// initial frame and iteration pointers
+	it.Next()
+	it.Next()
+	// begin frame of the entries
+	it.Next()
+	var entries []hazelcast.Pair
+	for it.HasNext() && !it.PeekNext().IsEndFrame() {
+		key := hazelcast.Data(it.Next().Content)
+		value := hazelcast.Data(it.Next().Content)
+		entries = append(entries, hazelcast.NewPair(key, value))
+	}
+	return entries
+}
+
+// newFetchSchemaRequest encodes the ClientFetchSchema request for the schema of compact objects.
+func newFetchSchemaRequest(schemaID int64) *hazelcast.ClientMessage {
+	req := hazelcast.NewClientMessageForEncode()
+	req.SetRetryable(true)
+	initial := make([]byte, hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes+hazelcast.LongSizeInBytes)
+	binary.LittleEndian.PutUint64(initial[hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes:], uint64(schemaID))
+	req.AddFrame(hazelcast.NewFrameWith(initial, hazelcast.UnfragmentedMessage))
+	req.SetMessageType(clientFetchSchemaRequestType)
+	req.SetPartitionId(-1)
+	return req
+}
+
+// decodeFetchSchemaResponse returns the type name of the schema in the ClientFetchSchema response.
+func decodeFetchSchemaResponse(resp *hazelcast.ClientMessage) (string, error) {
+	it := resp.FrameIterator()
+	// initial frame
+	it.Next()
+	if !it.HasNext() || it.Next().IsNullFrame() || !it.HasNext() {
+		return "", errors.New("the schema of compact objects is not found, provide their format with --key-format or --value-format")
+	}
+	// the type name follows the begin frame of the schema
+	return string(it.Next().Content), nil
+}
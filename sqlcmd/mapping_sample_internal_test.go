@@ -0,0 +1,154 @@
+//go:build hazelcastinternal
+// +build hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFetchEntriesRequest(t *testing.T) {
+	req := newFetchEntriesRequest("employees", 7, 100)
+	require.Equal(t, mapFetchEntriesRequestType, req.Type())
+	require.Equal(t, int32(7), req.PartitionID())
+	it := req.FrameIterator()
+	initial := it.Next().Content
+	require.Equal(t, uint32(100), binary.LittleEndian.Uint32(initial[hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes:]))
+	require.Equal(t, "employees", string(it.Next().Content))
+	pointers := it.Next().Content
+	require.Equal(t, uint32(math.MaxInt32), binary.LittleEndian.Uint32(pointers))
+	require.Equal(t, int32(-1), int32(binary.LittleEndian.Uint32(pointers[hazelcast.IntSizeInBytes:])))
+	require.False(t, it.HasNext())
+}
+
+func TestDecodeFetchEntriesResponse(t *testing.T) {
+	resp := newResponse(
+		hazelcast.NewFrame(make([]byte, 2*hazelcast.IntSizeInBytes)),
+		hazelcast.BeginFrame,
+		hazelcast.NewFrame([]byte("k1")),
+		hazelcast.NewFrame([]byte("v1")),
+		hazelcast.NewFrame([]byte("k2")),
+		hazelcast.NewFrame([]byte("v2")),
+		hazelcast.EndFrame,
+	)
+	entries := decodeFetchEntriesResponse(resp)
+	require.Equal(t, []hazelcast.Pair{
+		hazelcast.NewPair(hazelcast.Data("k1"), hazelcast.Data("v1")),
+		hazelcast.NewPair(hazelcast.Data("k2"), hazelcast.Data("v2")),
+	}, entries)
+	empty := newResponse(hazelcast.NewFrame(make([]byte, 2*hazelcast.IntSizeInBytes)), hazelcast.BeginFrame, hazelcast.EndFrame)
+	require.Empty(t, decodeFetchEntriesResponse(empty))
+}
+
+func TestNewFetchSchemaRequest(t *testing.T) {
+	req := newFetchSchemaRequest(-42)
+	require.Equal(t, clientFetchSchemaRequestType, req.Type())
+	require.Equal(t, int32(-1), req.PartitionID())
+	initial := req.FrameIterator().Next().Content
+	require.Equal(t, int64(-42), int64(binary.LittleEndian.Uint64(initial[hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes:])))
+}
+
+func TestDecodeFetchSchemaResponse(t *testing.T) {
+	name, err := decodeFetchSchemaResponse(newResponse(hazelcast.BeginFrame, hazelcast.NewFrame([]byte("employee")), hazelcast.EndFrame))
+	require.NoError(t, err)
+	require.Equal(t, "employee", name)
+	_, err = decodeFetchSchemaResponse(newResponse(hazelcast.NullFrame))
+	require.Error(t, err)
+}
+
+func TestSampledObject(t *testing.T) {
+	decode := func(data hazelcast.Data) (interface{}, error) {
+		return "decoded", nil
+	}
+	typeName := func(schemaID int64) (string, error) {
+		if schemaID == 12 {
+			return "employee", nil
+		}
+		return "", errors.New("unknown schema")
+	}
+	tcs := []struct {
+		name   string
+		data   hazelcast.Data
+		object interface{}
+		isErr  bool
+	}{
+		{
+			name:   "portable",
+			data:   serialized(typePortable, 1, 2, 3),
+			object: portableObject{factoryID: 1, classID: 2, version: 3},
+		},
+		{
+			name:   "compact",
+			data:   serialized(typeCompact, 0, 12),
+			object: compactObject{typeName: "employee"},
+		},
+		{
+			name:  "unknown compact schema",
+			data:  serialized(typeCompact, 0, 13),
+			isErr: true,
+		},
+		{
+			name:  "truncated portable",
+			data:  serialized(typePortable, 1),
+			isErr: true,
+		},
+		{
+			name:   "other types are decoded",
+			data:   serialized(-11, 5),
+			object: "decoded",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			object, err := sampledObject(tc.data, decode, typeName)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.object, object)
+		})
+	}
+}
+
+// newResponse returns a response message with the given frames after the initial frame.
+func newResponse(frames ...hazelcast.Frame) *hazelcast.ClientMessage {
+	resp := hazelcast.NewClientMessageForEncode()
+	resp.AddFrame(hazelcast.NewFrameWith(make([]byte, hazelcast.PartitionIDOffset+hazelcast.IntSizeInBytes), hazelcast.UnfragmentedMessage))
+	for _, f := range frames {
+		resp.AddFrame(f)
+	}
+	return resp
+}
+
+// serialized returns the serialized object of the given type with the big endian 32-bit payload.
+func serialized(typeID int32, payload ...int32) hazelcast.Data {
+	data := make([]byte, dataOffset+4*len(payload))
+	binary.BigEndian.PutUint32(data[4:], uint32(typeID))
+	for i, p := range payload {
+		binary.BigEndian.PutUint32(data[dataOffset+4*i:], uint32(p))
+	}
+	return data
+}
@@ -0,0 +1,57 @@
+//go:build hazelcastinternal
+// +build hazelcastinternal
+
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	hz "github.com/hazelcast/hazelcast-go-client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/connection"
+	"github.com/hazelcast/hazelcast-commandline-client/internal/it"
+	"github.com/hazelcast/hazelcast-commandline-client/sqlcmd"
+)
+
+func TestGenerateMapping_Portable(t *testing.T) {
+	it.SQLTesterWithConfigBuilder(t, nil, func(t *testing.T, client *hz.Client, config *hz.Config, m *hz.Map, mapName string) {
+		ctx := context.Background()
+		defer func() {
+			require.NoError(t, connection.Disconnect(ctx))
+			connection.ResetClient()
+		}()
+		for _, k := range []string{"k1", "k2", "k3"} {
+			require.NoError(t, m.Set(ctx, k, it.SamplePortable{A: k, B: 1}))
+		}
+		cmd := sqlcmd.NewGenerateMapping(config)
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stdout)
+		cmd.SetArgs([]string{"--map", mapName, "--sample", "2"})
+		_, err := cmd.ExecuteContextC(ctx)
+		require.NoError(t, err)
+		require.Contains(t, stdout.String(), "'keyFormat' = 'varchar'")
+		require.Contains(t, stdout.String(), "'valueFormat' = 'portable'")
+		require.Contains(t, stdout.String(), "'valuePortableFactoryId' = '1'")
+		require.Contains(t, stdout.String(), "'valuePortableClassId' = '1'")
+	})
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"testing"
+
+	"github.com/hazelcast/hazelcast-go-client/serialization"
+	"github.com/hazelcast/hazelcast-go-client/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInferFormat(t *testing.T) {
+	tcs := []struct {
+		name    string
+		samples []interface{}
+		format  serializationFormat
+		isErr   bool
+	}{
+		{
+			name:    "strings",
+			samples: []interface{}{"a", nil, "b"},
+			format:  serializationFormat{name: "varchar", columns: []mappingColumn{{"this", sqlVarchar}}},
+		},
+		{
+			name:    "integers are widened",
+			samples: []interface{}{int32(1), int64(2), int16(3)},
+			format:  serializationFormat{name: "bigint", columns: []mappingColumn{{"this", sqlBigint}}},
+		},
+		{
+			name: "flat JSON keeps the field order",
+			samples: []interface{}{
+				serialization.JSON(`{"name":"Joe","age":22,"manager":null}`),
+				serialization.JSON(`{"name":"Jane","age":41.5,"manager":true}`),
+			},
+			format: serializationFormat{name: formatJSONFlat, columns: []mappingColumn{
+				{"name", sqlVarchar},
+				{"age", sqlDouble},
+				{"manager", sqlBoolean},
+			}},
+		},
+		{
+			name: "nested JSON",
+			samples: []interface{}{
+				serialization.JSON(`{"name":"Joe","address":{"city":"London"}}`),
+			},
+			format: serializationFormat{name: formatJSON, columns: []mappingColumn{{"this", sqlJSON}}},
+		},
+		{
+			name:    "decimal wins over floating point",
+			samples: []interface{}{float32(1.5), types.Decimal{}, float64(2.5)},
+			format:  serializationFormat{name: "decimal", columns: []mappingColumn{{"this", sqlDecimal}}},
+		},
+		{
+			name:    "portable",
+			samples: []interface{}{portableObject{factoryID: 1, classID: 2, version: 3}, nil},
+			format: serializationFormat{name: formatPortable, options: [][2]string{
+				{"valuePortableFactoryId", "1"},
+				{"valuePortableClassId", "2"},
+				{"valuePortableClassVersion", "3"},
+			}},
+		},
+		{
+			name:    "compact",
+			samples: []interface{}{compactObject{typeName: "Employee"}},
+			format:  serializationFormat{name: formatCompact, options: [][2]string{{"valueCompactTypeName", "Employee"}}},
+		},
+		{
+			name:    "mixed compact types",
+			samples: []interface{}{compactObject{typeName: "Employee"}, compactObject{typeName: "Manager"}},
+			isErr:   true,
+		},
+		{
+			name:    "mixed types",
+			samples: []interface{}{"a", true},
+			isErr:   true,
+		},
+		{
+			name:    "only nulls",
+			samples: []interface{}{nil},
+			isErr:   true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := inferFormat(tc.samples, "this", "value")
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.format, *f)
+		})
+	}
+}
+
+func TestParseFormatFlag(t *testing.T) {
+	f, err := parseFormatFlag("portable:1:2", "value")
+	require.NoError(t, err)
+	require.Equal(t, serializationFormat{name: formatPortable, options: [][2]string{
+		{"valuePortableFactoryId", "1"},
+		{"valuePortableClassId", "2"},
+	}}, *f)
+	f, err = parseFormatFlag("compact:Person", "key")
+	require.NoError(t, err)
+	require.Equal(t, serializationFormat{name: formatCompact, options: [][2]string{{"keyCompactTypeName", "Person"}}}, *f)
+	f, err = parseFormatFlag("", "key")
+	require.NoError(t, err)
+	require.Nil(t, f)
+	_, err = parseFormatFlag("varchar", "key")
+	require.Error(t, err)
+	_, err = parseFormatFlag("portable:1", "key")
+	require.Error(t, err)
+}
+
+func TestMappingDDL(t *testing.T) {
+	key := &serializationFormat{name: "bigint", columns: []mappingColumn{{"__key", sqlBigint}}}
+	value := &serializationFormat{name: formatJSONFlat, columns: []mappingColumn{{"name", sqlVarchar}, {"age", sqlBigint}}}
+	require.Equal(t, `CREATE MAPPING "employees" (
+    __key BIGINT,
+    "name" VARCHAR,
+    "age" BIGINT
+)
+TYPE IMap
+OPTIONS (
+    'keyFormat' = 'bigint',
+    'valueFormat' = 'json-flat'
+)`, mappingDDL("employees", "employees", key, value, false))
+	value, err := parseFormatFlag("compact:Employee", "value")
+	require.NoError(t, err)
+	require.Equal(t, `CREATE OR REPLACE MAPPING "emp" EXTERNAL NAME "employees"
+TYPE IMap
+OPTIONS (
+    'keyFormat' = 'bigint',
+    'valueFormat' = 'compact',
+    'valueCompactTypeName' = 'Employee'
+)`, mappingDDL("emp", "employees", key, value, true))
+}
@@ -30,7 +30,7 @@ import (
 	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
 )
 
-func query(ctx context.Context, c *hazelcast.Client, text string, out io.Writer, outputType string) error {
+func Query(ctx context.Context, c *hazelcast.Client, text string, out io.Writer, outputType string, wrap bool) error {
 	result, err := c.SQL().Execute(ctx, text)
 	if err != nil {
 		return fmt.Errorf("querying: %w", err)
@@ -47,30 +47,32 @@ func query(ctx context.Context, c *hazelcast.Client, text string, out io.Writer,
 		case <-ch:
 		}
 	}()
+	tWriter := table.NewTableWriter(out)
+	tWriter.Wrap = wrap
+	columnHandler, rowHandler, flush := rowWriters(out, outputType, tWriter)
+	err = rowsHandler(result, columnHandler, rowHandler)
+	// write the rows held back to size the table columns, even if the query is canceled
+	if fErr := flush(); err == nil {
+		err = fErr
+	}
+	return err
+}
+
+// rowWriters returns the column and row handlers which write the result to out in the given output type.
+// CSV rows are written out as soon as the row handler is called, table rows may be held back until flush is called
+// to size the columns.
+func rowWriters(out io.Writer, outputType string, tWriter *table.TabularWriter) (columnHandler func(cols []sql.ColumnMetadata) error, rowHandler func([]interface{}) error, flush func() error) {
 	switch outputType {
-	case outputPretty:
-		tWriter := table.NewTableWriter(out)
-		return rowsHandler(result, func(cols []string) error {
-			icols := make([]interface{}, len(cols))
-			for i, v := range cols {
-				icols[i] = v
-			}
-			return tWriter.WriteHeader(icols...)
-		}, func(row []interface{}) error {
-			for i, v := range row {
-				row[i] = format.Fmt(v)
-			}
-			return tWriter.Write(row...)
-		})
-	case outputCSV:
+	case OutputCSV:
 		csvWriter := csv.NewWriter(out)
-		return rowsHandler(result, func(cols []string) error {
-			if err := csvWriter.Write(cols); err != nil {
+		columnHandler = func(cols []sql.ColumnMetadata) error {
+			if err := csvWriter.Write(columnNames(cols)); err != nil {
 				return err
 			}
 			csvWriter.Flush()
 			return nil
-		}, func(values []interface{}) error {
+		}
+		rowHandler = func(values []interface{}) error {
 			strValues := make([]string, len(values))
 			for i, v := range values {
 				strValues[i] = format.Fmt(v)
@@ -80,21 +82,56 @@ func query(ctx context.Context, c *hazelcast.Client, text string, out io.Writer,
 			}
 			csvWriter.Flush()
 			return nil
-		})
+		}
+		flush = func() error {
+			return nil
+		}
+	default:
+		columnHandler = func(cols []sql.ColumnMetadata) error {
+			icols := make([]interface{}, len(cols))
+			for i, c := range cols {
+				icols[i] = c.Name()
+				if isNumeric(c.Type()) {
+					tWriter.AlignRight(i)
+				}
+			}
+			return tWriter.WriteHeader(icols...)
+		}
+		rowHandler = func(row []interface{}) error {
+			for i, v := range row {
+				row[i] = format.Fmt(v)
+			}
+			return tWriter.Write(row...)
+		}
+		flush = tWriter.Flush
 	}
-	return nil
+	return columnHandler, rowHandler, flush
+}
+
+func columnNames(cols []sql.ColumnMetadata) []string {
+	names := make([]string, len(cols))
+	for i, c := range cols {
+		names[i] = c.Name()
+	}
+	return names
+}
+
+func isNumeric(t sql.ColumnType) bool {
+	switch t {
+	case sql.ColumnTypeTinyInt, sql.ColumnTypeSmallInt, sql.ColumnTypeInt, sql.ColumnTypeBigInt,
+		sql.ColumnTypeDecimal, sql.ColumnTypeReal, sql.ColumnTypeDouble:
+		return true
+	}
+	return false
 }
 
 // Reads columns and rows calls handlers. rowHandler is called per row.
-func rowsHandler(result sql.Result, columnHandler func(cols []string) error, rowHandler func([]interface{}) error) error {
+func rowsHandler(result sql.Result, columnHandler func(cols []sql.ColumnMetadata) error, rowHandler func([]interface{}) error) error {
 	mt, err := result.RowMetadata()
 	if err != nil {
 		return fmt.Errorf("retrieving metadata: %w", err)
 	}
-	var cols []string
-	for _, c := range mt.Columns() {
-		cols = append(cols, c.Name())
-	}
+	cols := mt.Columns()
 	if err = columnHandler(cols); err != nil {
 		return err
 	}
@@ -107,13 +144,9 @@ func rowsHandler(result sql.Result, columnHandler func(cols []string) error, row
 		if err != nil {
 			return fmt.Errorf("fetching row: %w", err)
 		}
-		values := make([]interface{}, len(cols))
-		for i := 0; i < len(cols); i++ {
-			v, err := row.Get(i)
-			if err != nil {
-				return fmt.Errorf("fetching value: %w", err)
-			}
-			values[i] = v
+		values, err := rowValues(row, len(cols))
+		if err != nil {
+			return err
 		}
 		if err := rowHandler(values); err != nil {
 			return err
@@ -122,6 +155,18 @@ func rowsHandler(result sql.Result, columnHandler func(cols []string) error, row
 	return nil
 }
 
+func rowValues(row sql.Row, colCount int) ([]interface{}, error) {
+	values := make([]interface{}, colCount)
+	for i := 0; i < colCount; i++ {
+		v, err := row.Get(i)
+		if err != nil {
+			return nil, fmt.Errorf("fetching value: %w", err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
 func execute(ctx context.Context, c *hazelcast.Client, text string) (sql.Result, error) {
 	r, err := c.SQL().Execute(ctx, text)
 	if err != nil {
@@ -18,6 +18,7 @@ package sqlcmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hazelcast/hazelcast-go-client"
 	"github.com/spf13/cobra"
@@ -28,22 +29,39 @@ import (
 )
 
 const (
-	outputPretty = "pretty"
-	outputCSV    = "csv"
+	OutputPretty = "pretty"
+	OutputCSV    = "csv"
 )
 
 func New(config *hazelcast.Config) *cobra.Command {
-	var outputType string
+	var (
+		outputType string
+		wrap       bool
+		stream     bool
+		limit      int
+		duration   time.Duration
+	)
 	cmd := &cobra.Command{
 		Use:   "sql [query]",
 		Short: "Start SQL Browser or execute given SQL query",
 		Example: `sql 	# starts the SQL Browser
-sql "CREATE MAPPING IF NOT EXISTS myMap (__key VARCHAR, this VARCHAR) TYPE IMAP OPTIONS ( 'keyFormat' = 'varchar', 'valueFormat' = 'varchar')" 	# executes the query`,
+sql "CREATE MAPPING IF NOT EXISTS myMap (__key VARCHAR, this VARCHAR) TYPE IMAP OPTIONS ( 'keyFormat' = 'varchar', 'valueFormat' = 'varchar')" 	# executes the query
+sql "SELECT * FROM TABLE(generate_stream(10))" --stream --limit 100 	# streams the rows of a never ending query
+sql "SELECT * FROM myMap" --wrap 	# wraps long values in the table instead of truncating them
+sql generate-mapping --map myMap 	# prints the mapping of myMap inferred from its entries
+sql explain "SELECT * FROM myMap WHERE this = 'a'" 	# shows the execution plan of the query
+sql bench "SELECT * FROM myMap WHERE this = 'a'" --iterations 1000 	# reports the throughput and latency of the query`,
+		// queries are given as arguments, which must not be mistaken for unknown subcommands
+		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if outputType != outputPretty && outputType != outputCSV {
-				return hzcerrors.NewLoggableError(nil,
-					"Provided output type parameter (%s) is not a known type. Provide either '%s' or '%s'",
-					outputType, outputPretty, outputCSV)
+			if err := ValidateOutputType(outputType); err != nil {
+				return err
+			}
+			if !stream && (limit != 0 || duration != 0) {
+				return hzcerrors.NewLoggableError(nil, "--limit and --duration can only be used together with --stream")
+			}
+			if limit < 0 || duration < 0 {
+				return hzcerrors.NewLoggableError(nil, "--limit and --duration must be positive")
 			}
 			ctx := cmd.Context()
 			c, err := connection.ConnectToCluster(ctx, config)
@@ -64,13 +82,24 @@ sql "CREATE MAPPING IF NOT EXISTS myMap (__key VARCHAR, this VARCHAR) TYPE IMAP
 			}
 			// If a statement is provided, run it in non-interactive mode
 			lt := strings.ToLower(q)
-			if strings.HasPrefix(lt, "select") || strings.HasPrefix(lt, "show") {
-				if err := query(ctx, c, q, cmd.OutOrStdout(), outputType); err != nil && !isContextCancellationErr(err) {
+			isRowQuery := strings.HasPrefix(lt, "select") || strings.HasPrefix(lt, "show")
+			if stream {
+				if !isRowQuery {
+					return hzcerrors.NewLoggableError(nil, "--stream can only be used with queries that return rows")
+				}
+				opts := streamOptions{limit: limit, duration: duration, wrap: wrap}
+				if err := streamQuery(ctx, c, q, cmd.OutOrStdout(), cmd.ErrOrStderr(), outputType, opts); err != nil {
+					return hzcerrors.NewLoggableError(err, "Cannot execute the query")
+				}
+				return nil
+			}
+			if isRowQuery {
+				if err := Query(ctx, c, q, cmd.OutOrStdout(), outputType, wrap); err != nil && !IsContextCancellationErr(err) {
 					return hzcerrors.NewLoggableError(err, "Cannot execute the query")
 				}
 			} else {
 				r, err := execute(ctx, c, q)
-				if err != nil && !isContextCancellationErr(err) {
+				if err != nil && !IsContextCancellationErr(err) {
 					return hzcerrors.NewLoggableError(err, "Cannot execute the query")
 				}
 				uc := r.UpdateCount()
@@ -82,11 +111,14 @@ sql "CREATE MAPPING IF NOT EXISTS myMap (__key VARCHAR, this VARCHAR) TYPE IMAP
 			return nil
 		},
 	}
-	decorateCommandWithOutputFlag(&outputType, cmd)
+	DecorateCommandWithOutputFlag(&outputType, cmd)
+	cmd.Flags().BoolVar(&wrap, "wrap", false, fmt.Sprintf("wrap long values in the table instead of truncating them [--output-type %s]", OutputPretty))
+	decorateCommandWithStreamFlags(&stream, &limit, &duration, cmd)
+	cmd.AddCommand(NewGenerateMapping(config), NewExplain(config), NewBench(config))
 	return cmd
 }
 
-func isContextCancellationErr(err error) bool {
+func IsContextCancellationErr(err error) bool {
 	errTxt := err.Error()
 	// todo find a better way to detect user ended the query
 	if strings.Contains(errTxt, "context canceled") {
@@ -96,10 +128,26 @@ func isContextCancellationErr(err error) bool {
 	return false
 }
 
-func decorateCommandWithOutputFlag(outputType *string, cmd *cobra.Command) {
+func ValidateOutputType(outputType string) error {
+	if outputType != OutputPretty && outputType != OutputCSV {
+		return hzcerrors.NewLoggableError(nil,
+			"Provided output type parameter (%s) is not a known type. Provide either '%s' or '%s'",
+			outputType, OutputPretty, OutputCSV)
+	}
+	return nil
+}
+
+func DecorateCommandWithOutputFlag(outputType *string, cmd *cobra.Command) {
 	flags := cmd.Flags()
-	flags.StringVarP(outputType, "output-type", "o", outputPretty, fmt.Sprintf("%s or %s", outputPretty, outputCSV))
+	flags.StringVarP(outputType, "output-type", "o", OutputPretty, fmt.Sprintf("%s or %s", OutputPretty, OutputCSV))
 	cmd.RegisterFlagCompletionFunc("output-type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{outputPretty, outputCSV}, cobra.ShellCompDirectiveDefault
+		return []string{OutputPretty, OutputCSV}, cobra.ShellCompDirectiveDefault
 	})
 }
+
+func decorateCommandWithStreamFlags(stream *bool, limit *int, duration *time.Duration, cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.BoolVar(stream, "stream", false, "print each row as soon as it arrives and report the row rate, for never ending queries")
+	flags.IntVar(limit, "limit", 0, "stop streaming after the given number of rows [--stream]")
+	flags.DurationVar(duration, "duration", 0, "stop streaming after the given duration, e.g. 30s [--stream]")
+}
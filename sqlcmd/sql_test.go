@@ -18,7 +18,7 @@ import (
 )
 
 func TestSQLCmd(t *testing.T) {
-	// set consoleSize for proper SQL table output
+	// set consoleSize for proper SQL table output, the values are not truncated since the output is not a terminal
 	table.ConsoleSize = func() (int, int) {
 		return 100, 100
 	}
@@ -70,17 +70,17 @@ Affected rows: 0
 			{
 				name: "valid select query",
 				args: []string{selectQry},
-				output: `+-------------------------------------------------------------------------------------------------+
-|                      __key                     |                      this                      |
-+-------------------------------------------------------------------------------------------------+
-| 1                                              | {"countries":"United Kingdom","cities":"Lon... |
-| 2                                              | {"countries":"United Kingdom","cities":"Man... |
-| 3                                              | {"countries":"United States","cities":"New ... |
-| 4                                              | {"countries":"United States","cities":"Los ... |
-| 5                                              | {"countries":"Turkey","cities":"Ankara"}       |
-| 6                                              | {"countries":"Turkey","cities":"Istanbul"}     |
-| 7                                              | {"countries":"Brazil","cities":"Sao Paulo"}    |
-| 8                                              | {"countries":"Brazil","cities":"Rio de Jane... |
+				output: `+--------------------------------------------------------------+
+| __key |                         this                         |
++--------------------------------------------------------------+
+|     1 | {"countries":"United Kingdom","cities":"London"}     |
+|     2 | {"countries":"United Kingdom","cities":"Manchester"} |
+|     3 | {"countries":"United States","cities":"New York"}    |
+|     4 | {"countries":"United States","cities":"Los Angeles"} |
+|     5 | {"countries":"Turkey","cities":"Ankara"}             |
+|     6 | {"countries":"Turkey","cities":"Istanbul"}           |
+|     7 | {"countries":"Brazil","cities":"Sao Paulo"}          |
+|     8 | {"countries":"Brazil","cities":"Rio de Janeiro"}     |
 `,
 			},
 			{
@@ -144,3 +144,24 @@ func TestSQL_CancelContext(t *testing.T) {
 		require.NoError(t, w.Close())
 	})
 }
+
+func TestSQL_StreamWithLimit(t *testing.T) {
+	it.SQLTester(t, func(t *testing.T, client *hz.Client, config *hz.Config, m *hz.Map, mapName string) {
+		cmd := sqlcmd.New(config)
+		var out, status bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&status)
+		cmd.SetArgs([]string{"select * from table(generate_stream(100))", "--stream", "--limit", "5", "-o", "csv"})
+		_, err := cmd.ExecuteContextC(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "v\n0\n1\n2\n3\n4\n", out.String())
+		require.Contains(t, status.String(), "--- 5 rows")
+	})
+}
+
+func TestSQL_StreamFlagsRequireStream(t *testing.T) {
+	cmd := sqlcmd.New(&hz.Config{})
+	cmd.SetArgs([]string{"select * from table(generate_stream(100))", "--limit", "5"})
+	_, err := cmd.ExecuteContextC(context.Background())
+	require.Error(t, err)
+}
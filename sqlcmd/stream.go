@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2008-2021, Hazelcast, Inc. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License")
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sqlcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/hazelcast/hazelcast-go-client"
+	"github.com/hazelcast/hazelcast-go-client/sql"
+
+	"github.com/hazelcast/hazelcast-commandline-client/internal/table"
+)
+
+// streamStatusInterval is the period of the status line written while streaming.
+const streamStatusInterval = 5 * time.Second
+
+type streamOptions struct {
+	// limit is the number of rows after which streaming stops, 0 is no limit
+	limit int
+	// duration is the time after which streaming stops, 0 is no limit
+	duration time.Duration
+	// wrap wraps the long values in the table output instead of truncating them
+	wrap bool
+}
+
+// streamStats is updated by the row reader and read by the status reporter.
+type streamStats struct {
+	start time.Time
+	rows  int64
+}
+
+func (s *streamStats) String() string {
+	elapsed := time.Since(s.start)
+	rows := atomic.LoadInt64(&s.rows)
+	var rate float64
+	if secs := elapsed.Seconds(); secs > 0 {
+		rate = float64(rows) / secs
+	}
+	return fmt.Sprintf("--- %d rows, %.1f rows/sec, elapsed %s", rows, rate, elapsed.Truncate(time.Millisecond))
+}
+
+// streamQuery writes every row of the query to out as soon as it is received, and periodically writes
+// the row rate to status. It stops when the result is exhausted, the row limit or the duration is reached
+// or ctx is canceled, e.g. on Ctrl+C. The result is closed before streamQuery returns in all cases.
+func streamQuery(ctx context.Context, c *hazelcast.Client, text string, out, status io.Writer, outputType string, opts streamOptions) error {
+	// canceling the query context makes the client close the result, which also interrupts a blocked fetch
+	queryCtx, cancelQuery := context.WithCancel(ctx)
+	defer cancelQuery()
+	result, err := c.SQL().Execute(queryCtx, text)
+	if err != nil {
+		return fmt.Errorf("querying: %w", err)
+	}
+	stopCtx, stop := context.WithCancel(queryCtx)
+	defer stop()
+	if opts.duration > 0 {
+		stopCtx, stop = context.WithTimeout(stopCtx, opts.duration)
+		defer stop()
+	}
+	var stopped int32
+	go func() {
+		<-stopCtx.Done()
+		atomic.StoreInt32(&stopped, 1)
+		cancelQuery()
+	}()
+	stats := &streamStats{start: time.Now()}
+	reported := make(chan struct{})
+	go func() {
+		defer close(reported)
+		reportStreamStatus(stopCtx, status, stats)
+	}()
+	tWriter := table.NewStreamingTableWriter(out)
+	tWriter.Wrap = opts.wrap
+	columnHandler, rowHandler, flush := rowWriters(out, outputType, tWriter)
+	err = streamRows(result, stats, opts.limit, columnHandler, rowHandler)
+	if fErr := flush(); err == nil {
+		err = fErr
+	}
+	// the errors of the fetches interrupted by a stop condition are not reported
+	interrupted := atomic.LoadInt32(&stopped) == 1
+	// stop the status reporter and the query, then close the result, which is a no-op if the client closed it
+	// on the cancellation already
+	stop()
+	cancelQuery()
+	closeErr := result.Close()
+	// the status reporter must not write concurrently with the final status line
+	<-reported
+	_, _ = fmt.Fprintln(status, stats)
+	if err != nil && !interrupted {
+		return err
+	}
+	if closeErr != nil {
+		return fmt.Errorf("closing the result: %w", closeErr)
+	}
+	return nil
+}
+
+func streamRows(result sql.Result, stats *streamStats, limit int, columnHandler func(cols []sql.ColumnMetadata) error, rowHandler func([]interface{}) error) error {
+	mt, err := result.RowMetadata()
+	if err != nil {
+		return fmt.Errorf("retrieving metadata: %w", err)
+	}
+	cols := mt.Columns()
+	if err = columnHandler(cols); err != nil {
+		return err
+	}
+	it, err := result.Iterator()
+	if err != nil {
+		return fmt.Errorf("initializing result iterator: %w", err)
+	}
+	for it.HasNext() {
+		row, err := it.Next()
+		if err != nil {
+			return fmt.Errorf("fetching row: %w", err)
+		}
+		values, err := rowValues(row, len(cols))
+		if err != nil {
+			return err
+		}
+		if err := rowHandler(values); err != nil {
+			return err
+		}
+		if n := atomic.AddInt64(&stats.rows, 1); limit > 0 && n >= int64(limit) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func reportStreamStatus(ctx context.Context, status io.Writer, stats *streamStats) {
+	ticker := time.NewTicker(streamStatusInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_, _ = fmt.Fprintln(status, stats)
+		case <-ctx.Done():
+			return
+		}
+	}
+}